@@ -18,9 +18,27 @@ func ToAnyStringableAdaptor[T any](adapt StringableAdaptor[T]) (reflect.Type, An
 	}
 }
 
-var builtinStringableAdaptors = make(map[reflect.Type]AnyStringableAdaptor)
+// builtinAdaptor is the adaptor of a builtin type, which can be configured
+// by the options passed to Namespace.New.
+type builtinAdaptor func(v any, opts *options) (Stringable, error)
+
+var builtinStringableAdaptors = make(map[reflect.Type]builtinAdaptor)
 
 func builtinStringable[T any](adaptor StringableAdaptor[T]) {
 	typ, anyAdaptor := ToAnyStringableAdaptor[T](adaptor)
-	builtinStringableAdaptors[typ] = anyAdaptor
+	builtinStringableAdaptors[typ] = func(v any, _ *options) (Stringable, error) {
+		return anyAdaptor(v)
+	}
+}
+
+// builtinConfigurableStringable registers a builtin adaptor that depends on
+// the options passed to Namespace.New.
+func builtinConfigurableStringable[T any](adaptor func(*T, *options) (Stringable, error)) {
+	builtinStringableAdaptors[typeOf[T]()] = func(v any, opts *options) (Stringable, error) {
+		if cv, ok := v.(*T); ok {
+			return adaptor(cv, opts)
+		} else {
+			return nil, fmt.Errorf("%w: cannot convert %T to %s", ErrTypeMismatch, v, typeOf[*T]())
+		}
+	}
 }
@@ -0,0 +1,31 @@
+package stringable
+
+import (
+	"fmt"
+
+	"github.com/ggicci/stringable/internal"
+)
+
+// BigOptions configures how big.Int, big.Float and big.Rat values are
+// converted from/to a string, see WithBig.
+type BigOptions = internal.BigOptions
+
+// WithBig sets the options used to convert big.Int, big.Float and big.Rat
+// values. New fails with ErrInvalidOption if the options are invalid, e.g.
+// an invalid FloatFormat.
+//
+// Example:
+//
+//	stringable.New(&amount, stringable.WithBig(stringable.BigOptions{
+//		FloatPrec: 256,
+//		RatFormat: ".18f",
+//	}))
+func WithBig(o BigOptions) Option {
+	return func(opts *options) {
+		if err := internal.ValidateBigOptions(o); err != nil {
+			opts.err = fmt.Errorf("%w: %v", ErrInvalidOption, err)
+			return
+		}
+		opts.Big = &o
+	}
+}
@@ -0,0 +1,94 @@
+package stringable
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_BigInt(t *testing.T) {
+	var n big.Int
+	sb, err := New(&n)
+	assert.NoError(t, err)
+
+	assert.NoError(t, sb.FromString("123456789012345678901234567890"))
+	s, err := sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "123456789012345678901234567890", s)
+
+	assert.NoError(t, sb.FromString("0xff"))
+	assert.Equal(t, int64(255), n.Int64())
+	assert.Error(t, sb.FromString("hello"))
+	assert.Equal(t, int64(255), n.Int64(), "value should not change on error")
+
+	opts := WithBig(BigOptions{IntBase: 16})
+	v, err := Parse[big.Int]("ff", opts)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(255), v.Int64())
+	s, err = Format(v, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "ff", s)
+
+	_, err = Format(v, WithBig(BigOptions{IntBase: 63}))
+	assert.ErrorIs(t, err, ErrInvalidOption)
+	_, err = Parse[big.Int]("1", WithBig(BigOptions{IntBase: 1}))
+	assert.ErrorIs(t, err, ErrInvalidOption)
+}
+
+func TestNew_BigIntPointer(t *testing.T) {
+	var id *big.Int
+	sb, err := New(&id)
+	assert.NoError(t, err)
+	assert.NoError(t, sb.FromString("18446744073709551616"))
+	assert.Equal(t, "18446744073709551616", id.String())
+}
+
+func TestNew_BigFloat(t *testing.T) {
+	v, err := Parse[big.Float]("3.14159265358979323846264338327950288419716939937510582097494459")
+	assert.NoError(t, err)
+	assert.Equal(t, uint(64), v.Prec())
+	s, err := Format(v)
+	assert.NoError(t, err)
+	assert.Equal(t, "3.1415926535897932385", s)
+
+	opts := WithBig(BigOptions{FloatPrec: 256, FloatMode: big.ToZero, FloatFormat: ".30f"})
+	v, err = Parse[big.Float]("3.14159265358979323846264338327950288419716939937510582097494459", opts)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(256), v.Prec())
+	assert.Equal(t, big.ToZero, v.Mode())
+	s, err = Format(v, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "3.141592653589793238462643383280", s)
+
+	_, err = Parse[big.Float]("hello")
+	assert.Error(t, err)
+	_, err = New(v, WithBig(BigOptions{FloatFormat: "x"}))
+	assert.ErrorIs(t, err, ErrInvalidOption)
+}
+
+func TestNew_BigRat(t *testing.T) {
+	for input, expected := range map[string]string{
+		"1/3":   "1/3",
+		"6/3":   "2",
+		"0.125": "1/8",
+		"-1e-3": "-1/1000",
+	} {
+		v, err := Parse[big.Rat](input)
+		assert.NoError(t, err, input)
+		s, err := Format(v)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, s, input)
+	}
+
+	s, err := Format(*big.NewRat(2, 3), WithBig(BigOptions{RatFormat: ".4f"}))
+	assert.NoError(t, err)
+	assert.Equal(t, "0.6667", s)
+
+	for _, format := range []string{"e", "f", ".2e"} {
+		_, err = New(new(big.Rat), WithBig(BigOptions{RatFormat: format}))
+		assert.ErrorIs(t, err, ErrInvalidOption, format)
+	}
+	_, err = Parse[big.Rat]("1/0")
+	assert.Error(t, err)
+}
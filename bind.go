@@ -0,0 +1,438 @@
+package stringable
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// TagName is the name of the struct tag used by Bind and Unbind, e.g.
+// `stringable:"name"`.
+const TagName = "stringable"
+
+// Bind converts the values in src to the fields of the struct that dst
+// points to. Each field is converted with the Stringable created by
+// Namespace.New, from the value of its key in src:
+//
+//  1. the key is the name in the struct tag, e.g. `stringable:"port"`, or the
+//     field name if absent. The fields tagged with "-" and the unexported
+//     fields are skipped;
+//  2. a slice converted as a composite, e.g. []int, or a pointer to it, is
+//     converted from all the values of its key, i.e. the repeated keys,
+//     unless a separator is given in its struct tag. Other fields are
+//     converted from the first value;
+//  3. a struct field that can't be converted by Namespace.New, or a pointer
+//     to it, is bound recursively, with the keys prefixed by its key and a
+//     dot, e.g. "db.port". A nil pointer is allocated only if there are keys
+//     with the prefix in src;
+//  4. an embedded struct without a name in the struct tag, which can't be
+//     converted by Namespace.New, is flattened, i.e. its fields are bound
+//     with the same prefix as the embedding struct. Otherwise it's converted
+//     as a field named by its type, e.g. "Time" for an embedded time.Time;
+//  5. a field of any other unsupported type, e.g. func() or chan int, fails
+//     with ErrUnsupportedType only if its key is present in src or a default
+//     value is given in its struct tag.
+//
+// The struct tag can also configure the conversion of the field, e.g.
+// `stringable:"created,format=unix_ms"`, see WithStructField. The fields
+// whose keys are absent in src are left unchanged, unless a default value is
+// given in the struct tag, e.g. `stringable:"level,default=info"`. Bind doesn't
+// stop at the first error, it returns the errors of all the failed fields
+// joined by errors.Join, each one is a *FieldError.
+//
+// Example:
+//
+//	type Config struct {
+//		Host string   `stringable:"host"`
+//		Port uint16   `stringable:"port"`
+//		Tags []string `stringable:"tag"`
+//		DB   struct {
+//			DSN string `stringable:"dsn"`
+//		} `stringable:"db"`
+//	}
+//
+//	var config Config
+//	err := ns.Bind(&config, map[string][]string{
+//		"host":   {"localhost"},
+//		"port":   {"8080"},
+//		"tag":    {"a", "b"},
+//		"db.dsn": {"postgres://localhost"},
+//	})
+func (c *Namespace) Bind(dst any, src map[string][]string, opts ...Option) error {
+	rv, err := structValue(dst)
+	if err != nil {
+		return err
+	}
+	b := &binder{ns: c, opts: c.options(opts)}
+	b.bindStruct(rv, "", src)
+	return errors.Join(b.errs...)
+}
+
+// Unbind is the inverse of Bind, it converts the fields of the struct, or
+// the struct that src points to, to a map. The nil pointers and the empty
+// slices converted from the repeated keys are omitted. See Bind for the
+// rules of the keys. A field of an unsupported type fails only if it's not
+// the zero value, e.g. a non-nil func.
+func (c *Namespace) Unbind(src any, opts ...Option) (map[string][]string, error) {
+	if rv := reflect.ValueOf(src); rv.Kind() == reflect.Struct {
+		// Make a copy to get the addressable fields.
+		cp := reflect.New(rv.Type())
+		cp.Elem().Set(rv)
+		src = cp.Interface()
+	}
+	rv, err := structValue(src)
+	if err != nil {
+		return nil, err
+	}
+	b := &binder{ns: c, opts: c.options(opts)}
+	dst := make(map[string][]string)
+	b.unbindStruct(rv, "", dst)
+	return dst, errors.Join(b.errs...)
+}
+
+// Bind binds the values in src to the struct that dst points to in the
+// default namespace. See Namespace.Bind.
+func Bind(dst any, src map[string][]string, opts ...Option) error {
+	return defaultNS.Bind(dst, src, opts...)
+}
+
+// Unbind converts the fields of the struct src to a map in the default
+// namespace. See Namespace.Unbind.
+func Unbind(src any, opts ...Option) (map[string][]string, error) {
+	return defaultNS.Unbind(src, opts...)
+}
+
+func structValue(v any) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer {
+		return reflect.Value{}, fmt.Errorf("%w: value must be a non-nil pointer to a struct", ErrNotPointer)
+	}
+	if rv.IsNil() {
+		return reflect.Value{}, fmt.Errorf("%w: value must be a non-nil pointer to a struct", ErrNilPointer)
+	}
+	if rv.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, unsupportedType(rv.Type().Elem())
+	}
+	return rv.Elem(), nil
+}
+
+type binder struct {
+	ns   *Namespace
+	opts *options
+	errs []error
+
+	// binding is the chain of the struct types being bound, to stop the
+	// recursion of the embedded pointers, e.g. type Node struct{ *Node }.
+	binding []reflect.Type
+}
+
+func (b *binder) fail(key string, err error) {
+	b.errs = append(b.errs, &FieldError{Field: key, Err: err})
+}
+
+// field is a struct field to be converted, with its key resolved.
+type field struct {
+	sf       reflect.StructField
+	value    reflect.Value // the addressable field
+	key      string
+	prefix   string // the prefix of the keys of the struct having the field
+	embedded bool   // an embedded struct, flattened if it can't be converted
+
+	// flattenOnly is set for an unexported embedded struct, whose fields
+	// are flattened without trying to convert the struct itself.
+	flattenOnly bool
+}
+
+// nestedPrefix returns the prefix of the keys of the fields of a struct
+// field that is bound recursively.
+func (f field) nestedPrefix() string {
+	if f.embedded {
+		return f.prefix
+	}
+	return f.key + "."
+}
+
+// fields returns the fields of the struct rv to be converted.
+func fields(rv reflect.Value, prefix string) []field {
+	var result []field
+	typ := rv.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		name, _, _ := strings.Cut(sf.Tag.Get(TagName), ",")
+		if name == "-" {
+			continue
+		}
+		embedded := sf.Anonymous && name == "" && isStructOrPointerToStruct(sf.Type)
+		if !sf.IsExported() {
+			// The exported fields of an unexported embedded struct are
+			// still settable, but not the ones of a pointer to it. And the
+			// struct itself can't be converted.
+			if embedded && sf.Type.Kind() == reflect.Struct {
+				result = append(result, field{value: rv.Field(i), prefix: prefix, embedded: true, flattenOnly: true})
+			}
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		result = append(result, field{sf: sf, value: rv.Field(i), key: prefix + name, prefix: prefix, embedded: embedded})
+	}
+	return result
+}
+
+func isStructOrPointerToStruct(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ.Kind() == reflect.Struct
+}
+
+// fieldOptions returns the options of the field, with its struct tag
+// applied, see WithStructField.
+func (b *binder) fieldOptions(f field) *options {
+	opts := *b.opts
+	WithStructField(f.sf)(&opts)
+	return &opts
+}
+
+// isRepeated reports whether the field is converted from/to the repeated
+// keys, i.e. a slice converted as a composite, or a pointer to it, without a
+// separator in its struct tag.
+func (b *binder) isRepeated(f field, sb Stringable, opts *options) bool {
+	if opts.joined {
+		return false
+	}
+	typ := indirectType(f.value.Type())
+	if typ.Kind() != reflect.Slice {
+		return false
+	}
+	if typ != f.value.Type() {
+		var err error
+		if sb, err = b.ns.createStringable(reflect.New(typ), opts); err != nil {
+			return false
+		}
+	}
+	return sb.(*conversion).path == PathComposite
+}
+
+func indirectType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ
+}
+
+func (b *binder) bindStruct(rv reflect.Value, prefix string, src map[string][]string) {
+	b.binding = append(b.binding, rv.Type())
+	defer func() { b.binding = b.binding[:len(b.binding)-1] }()
+
+	for _, f := range fields(rv, prefix) {
+		if f.flattenOnly {
+			b.bindNested(f.value, f.prefix, src, true)
+			continue
+		}
+
+		opts := b.fieldOptions(f)
+		sb, err := b.ns.createStringable(f.value.Addr(), opts)
+		if err != nil {
+			if errors.Is(err, ErrUnsupportedType) && isStructOrPointerToStruct(f.value.Type()) {
+				b.bindNested(f.value, f.nestedPrefix(), src, f.embedded)
+			} else if _, ok := src[f.key]; ok || !errors.Is(err, ErrUnsupportedType) || opts.defaultValue != nil {
+				b.fail(f.key, err)
+			}
+			continue
+		}
+
+		values, ok := src[f.key]
+		if (!ok || len(values) == 0) && opts.defaultValue != nil {
+			values = []string{*opts.defaultValue}
+		}
+		if len(values) == 0 {
+			continue
+		}
+		if b.isRepeated(f, sb, opts) {
+			err = b.bindSlice(f.value, values, opts)
+		} else {
+			err = sb.FromString(values[0])
+		}
+		if err != nil {
+			b.fail(f.key, err)
+		}
+	}
+}
+
+// bindNested binds a struct or a pointer to a struct. A nil pointer is
+// allocated only if there are keys of the struct in src, i.e. the keys with
+// the prefix, or the keys of its fields if it's flattened, as the prefix is
+// shared with the embedding struct then.
+func (b *binder) bindNested(rv reflect.Value, prefix string, src map[string][]string, flattened bool) {
+	if rv.Kind() == reflect.Struct {
+		b.bindStruct(rv, prefix, src)
+		return
+	}
+	if !rv.IsNil() {
+		b.bindStruct(rv.Elem(), prefix, src)
+		return
+	}
+	if flattened && b.isBinding(rv.Type().Elem()) {
+		// The fields are shadowed by the ones of the embedding struct of
+		// the same type.
+		return
+	}
+	if flattened && !b.hasFieldKeys(rv.Type().Elem(), prefix, src, make(map[reflect.Type]bool)) ||
+		!flattened && !hasKeyWithPrefix(src, prefix) {
+		return
+	}
+	ptr := reflect.New(rv.Type().Elem())
+	b.bindStruct(ptr.Elem(), prefix, src)
+	rv.Set(ptr)
+}
+
+func hasKeyWithPrefix(src map[string][]string, prefix string) bool {
+	for key := range src {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *binder) isBinding(typ reflect.Type) bool {
+	for _, t := range b.binding {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// isFlattened reports whether the embedded field f is flattened, i.e. it
+// can't be converted by Namespace.New.
+func (b *binder) isFlattened(f field) bool {
+	_, err := b.ns.createStringable(f.value.Addr(), b.fieldOptions(f))
+	return errors.Is(err, ErrUnsupportedType)
+}
+
+// hasFieldKeys reports whether src has the keys of the fields of the struct
+// type typ, whose keys are prefixed by prefix. The types being visited are
+// skipped to stop the recursion of the embedded pointers, e.g.
+// type Node struct{ *Node }.
+func (b *binder) hasFieldKeys(typ reflect.Type, prefix string, src map[string][]string, visiting map[reflect.Type]bool) bool {
+	if visiting[typ] {
+		return false
+	}
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	for _, f := range fields(reflect.New(typ).Elem(), prefix) {
+		if f.flattenOnly || f.embedded && b.isFlattened(f) {
+			if b.hasFieldKeys(indirectType(f.value.Type()), f.prefix, src, visiting) {
+				return true
+			}
+			continue
+		}
+		if _, ok := src[f.key]; ok || hasKeyWithPrefix(src, f.key+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// bindSlice converts the values to the elements of the slice, or the
+// pointer to a slice, rv. The field is updated only if all the elements are
+// converted successfully, and a new slice is allocated to point to.
+func (b *binder) bindSlice(rv reflect.Value, values []string, opts *options) error {
+	target := reflect.MakeSlice(indirectType(rv.Type()), len(values), len(values))
+	var errs []error
+	for i, s := range values {
+		sb, err := b.ns.createStringable(target.Index(i).Addr(), opts)
+		if err == nil {
+			err = sb.FromString(s)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("index %d: %w", i, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	for rv.Kind() == reflect.Pointer {
+		ptr := reflect.New(rv.Type().Elem())
+		rv.Set(ptr)
+		rv = ptr.Elem()
+	}
+	rv.Set(target)
+	return nil
+}
+
+func (b *binder) unbindStruct(rv reflect.Value, prefix string, dst map[string][]string) {
+	for _, f := range fields(rv, prefix) {
+		if f.flattenOnly {
+			b.unbindNested(f.value, f.prefix, dst)
+			continue
+		}
+
+		opts := b.fieldOptions(f)
+		sb, err := b.ns.createStringable(f.value.Addr(), opts)
+		if err != nil {
+			if errors.Is(err, ErrUnsupportedType) && isStructOrPointerToStruct(f.value.Type()) {
+				b.unbindNested(f.value, f.nestedPrefix(), dst)
+			} else if !errors.Is(err, ErrUnsupportedType) || !f.value.IsZero() {
+				b.fail(f.key, err)
+			}
+			continue
+		}
+
+		if f.value.Kind() == reflect.Pointer && f.value.IsNil() {
+			continue
+		}
+		if b.isRepeated(f, sb, opts) {
+			values, err := b.unbindSlice(f.value, opts)
+			if err != nil {
+				b.fail(f.key, err)
+			} else if len(values) > 0 {
+				dst[f.key] = values
+			}
+			continue
+		}
+		s, err := sb.ToString()
+		if err != nil {
+			b.fail(f.key, err)
+			continue
+		}
+		dst[f.key] = []string{s}
+	}
+}
+
+// unbindNested unbinds a struct or a pointer to a struct.
+func (b *binder) unbindNested(rv reflect.Value, prefix string, dst map[string][]string) {
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return
+		}
+		rv = rv.Elem()
+	}
+	b.unbindStruct(rv, prefix, dst)
+}
+
+func (b *binder) unbindSlice(rv reflect.Value, opts *options) ([]string, error) {
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		rv = rv.Elem()
+	}
+	values := make([]string, rv.Len())
+	var errs []error
+	for i := range values {
+		sb, err := b.ns.createStringable(rv.Index(i).Addr(), opts)
+		if err == nil {
+			values[i], err = sb.ToString()
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("index %d: %w", i, err))
+		}
+	}
+	return values, errors.Join(errs...)
+}
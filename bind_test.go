@@ -0,0 +1,301 @@
+package stringable
+
+import (
+	"errors"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type BindDB struct {
+	DSN     string        `stringable:"dsn"`
+	Timeout time.Duration `stringable:"timeout"`
+}
+
+type BindMeta struct {
+	Owner string `stringable:"owner"`
+}
+
+type bindEmbedded struct {
+	Region string `stringable:"region"`
+}
+
+type BindConfig struct {
+	BindMeta
+	bindEmbedded
+	Host     string    `stringable:"host"`
+	Port     uint16    `stringable:"port"`
+	Tags     []string  `stringable:"tag"`
+	Ports    [2]int    `stringable:"ports"`
+	Debug    *bool     `stringable:"debug"`
+	Created  time.Time `stringable:"created"`
+	DB       BindDB    `stringable:"db"`
+	Replica  *BindDB   `stringable:"replica"`
+	Cache    *BindDB   `stringable:"cache"`
+	Untagged string
+	Ignored  string `stringable:"-"`
+	secret   string
+}
+
+func TestBind(t *testing.T) {
+	var config = BindConfig{Host: "unchanged"}
+	err := Bind(&config, map[string][]string{
+		"owner":           {"ops"},
+		"region":          {"eu"},
+		"port":            {"8080", "9090"},
+		"tag":             {"a", "b,c"},
+		"ports":           {"1,2"},
+		"debug":           {"true"},
+		"created":         {"2024-01-02T03:04:05Z"},
+		"db.dsn":          {"postgres://localhost"},
+		"db.timeout":      {"5s"},
+		"replica.timeout": {"1m"},
+		"Untagged":        {"yes"},
+		"Ignored":         {"no"},
+		"secret":          {"no"},
+	})
+	assert.NoError(t, err)
+
+	debug := true
+	assert.Equal(t, BindConfig{
+		BindMeta:     BindMeta{Owner: "ops"},
+		bindEmbedded: bindEmbedded{Region: "eu"},
+		Host:         "unchanged",
+		Port:         8080,
+		Tags:         []string{"a", "b,c"},
+		Ports:        [2]int{1, 2},
+		Debug:        &debug,
+		Created:      time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		DB:           BindDB{DSN: "postgres://localhost", Timeout: 5 * time.Second},
+		Replica:      &BindDB{Timeout: time.Minute},
+		Untagged:     "yes",
+	}, config)
+}
+
+func TestBind_Errors(t *testing.T) {
+	var config = BindConfig{Port: 80, Tags: []string{"x"}}
+	err := Bind(&config, map[string][]string{
+		"host":       {"localhost"},
+		"port":       {"http"},
+		"tag":        {"a"},
+		"ports":      {"1,x"},
+		"db.timeout": {"forever"},
+	})
+
+	var fe *FieldError
+	assert.True(t, errors.As(err, &fe))
+	assert.Equal(t, "port", fe.Field)
+	var ce *ConversionError
+	assert.True(t, errors.As(err, &ce))
+	assert.ErrorContains(t, err, `field "port": cannot convert "http" to uint16`)
+	assert.ErrorContains(t, err, `field "ports":`)
+	assert.ErrorContains(t, err, `field "db.timeout":`)
+	assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 3)
+
+	assert.Equal(t, "localhost", config.Host, "the other fields should still be bound")
+	assert.Equal(t, uint16(80), config.Port)
+	assert.Equal(t, []string{"a"}, config.Tags)
+
+	var ids []int
+	err = Bind(&struct {
+		IDs *[]int `stringable:"id"`
+		Bad []int  `stringable:"bad"`
+	}{IDs: &ids}, map[string][]string{"bad": {"1", "x", "3", "y"}})
+	assert.ErrorContains(t, err, "index 1:")
+	assert.ErrorContains(t, err, "index 3:")
+
+	assert.ErrorIs(t, Bind(BindConfig{}, nil), ErrNotPointer)
+	assert.ErrorIs(t, Bind(nil, nil), ErrNotPointer)
+	assert.ErrorIs(t, Bind((*BindConfig)(nil), nil), ErrNilPointer)
+	assert.ErrorIs(t, Bind(&ids, nil), ErrUnsupportedType)
+
+	var unsupported struct {
+		C    chan int
+		F    func() `stringable:"f,default=x"`
+		Name string `stringable:"name"`
+	}
+	err = Bind(&unsupported, map[string][]string{"name": {"a"}})
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+	assert.ErrorContains(t, err, `field "f"`)
+	assert.NotContains(t, err.Error(), `field "C"`, "should be skipped without its key")
+	assert.Equal(t, "a", unsupported.Name)
+	err = Bind(&struct{ C chan int }{}, map[string][]string{"C": {"1"}})
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+	assert.NoError(t, Bind(&struct{ C chan int }{}, map[string][]string{}))
+}
+
+func TestUnbind(t *testing.T) {
+	debug := false
+	config := BindConfig{
+		BindMeta:     BindMeta{Owner: "ops"},
+		bindEmbedded: bindEmbedded{Region: "eu"},
+		Host:         "localhost",
+		Port:         8080,
+		Tags:         []string{"a", "b,c"},
+		Ports:        [2]int{1, 2},
+		Debug:        &debug,
+		Created:      time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		DB:           BindDB{DSN: "postgres://localhost", Timeout: 5 * time.Second},
+		Ignored:      "no",
+		secret:       "no",
+	}
+	values, err := Unbind(config)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"owner":      {"ops"},
+		"region":     {"eu"},
+		"host":       {"localhost"},
+		"port":       {"8080"},
+		"tag":        {"a", "b,c"},
+		"ports":      {"1,2"},
+		"debug":      {"false"},
+		"created":    {"2024-01-02T03:04:05Z"},
+		"db.dsn":     {"postgres://localhost"},
+		"db.timeout": {"5s"},
+		"Untagged":   {""},
+	}, values)
+
+	var roundTrip BindConfig
+	assert.NoError(t, Bind(&roundTrip, values))
+	config.Ignored, config.secret = "", ""
+	assert.Equal(t, config, roundTrip)
+
+	values, err = Unbind(&struct {
+		C    chan int
+		Name string `stringable:"name"`
+	}{Name: "a"})
+	assert.NoError(t, err, "should skip the zero value of an unsupported type")
+	assert.Equal(t, map[string][]string{"name": {"a"}}, values)
+	_, err = Unbind(&struct{ C chan int }{C: make(chan int)})
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+	_, err = Unbind(42)
+	assert.ErrorIs(t, err, ErrNotPointer)
+	_, err = Unbind(nil)
+	assert.ErrorIs(t, err, ErrNotPointer)
+	_, err = Unbind((*BindConfig)(nil))
+	assert.ErrorIs(t, err, ErrNilPointer)
+	_, err = Unbind(&[]int{})
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+}
+
+func TestNamespace_Bind(t *testing.T) {
+	ns := NewNamespace()
+	Adapt(ns, func(b *bool) (Stringable, error) { return (*YesNo)(b), nil })
+
+	var v struct {
+		Enabled bool `stringable:"enabled"`
+	}
+	assert.NoError(t, ns.Bind(&v, map[string][]string{"enabled": {"yes"}}))
+	assert.True(t, v.Enabled)
+
+	values, err := ns.Unbind(&v)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"enabled": {"yes"}}, values)
+}
+
+type BindEmbeddedConvertible struct {
+	time.Time
+	*url.URL
+	big.Int `stringable:"count"`
+	BindMeta
+}
+
+func TestBind_EmbeddedConvertible(t *testing.T) {
+	var v BindEmbeddedConvertible
+	err := Bind(&v, map[string][]string{
+		"Time":  {"2024-01-02T03:04:05Z"},
+		"URL":   {"https://example.com"},
+		"count": {"42"},
+		"owner": {"ops"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), v.Time)
+	assert.Equal(t, "example.com", v.URL.Host)
+	assert.Equal(t, int64(42), v.Int.Int64())
+	assert.Equal(t, "ops", v.Owner)
+
+	values, err := Unbind(&v)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"Time":  {"2024-01-02T03:04:05Z"},
+		"URL":   {"https://example.com"},
+		"count": {"42"},
+		"owner": {"ops"},
+	}, values)
+}
+
+func TestBind_PointerToSlice(t *testing.T) {
+	var v struct {
+		IDs  *[]int     `stringable:"id"`
+		Tags **[]string `stringable:"tag"`
+		CSV  *[]int     `stringable:"csv,sep=;"`
+	}
+	old := []int{1}
+	v.IDs = &old
+	assert.NoError(t, Bind(&v, map[string][]string{
+		"id":  {"3", "4"},
+		"tag": {"a", "b"},
+		"csv": {"5;6"},
+	}))
+	assert.Equal(t, []int{3, 4}, *v.IDs)
+	assert.Equal(t, []int{1}, old, "a new slice should be allocated")
+	assert.Equal(t, []string{"a", "b"}, **v.Tags)
+	assert.Equal(t, []int{5, 6}, *v.CSV)
+
+	values, err := Unbind(&v)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"id":  {"3", "4"},
+		"tag": {"a", "b"},
+		"csv": {"5;6"},
+	}, values)
+
+	err = Bind(&v, map[string][]string{"id": {"7", "x"}})
+	assert.ErrorContains(t, err, "index 1:")
+	assert.Equal(t, []int{3, 4}, *v.IDs, "value should not change on error")
+
+	v.IDs = nil
+	values, err = Unbind(&v)
+	assert.NoError(t, err)
+	assert.NotContains(t, values, "id")
+}
+
+type BindInner struct {
+	Level string `stringable:"level"`
+	*BindInnerMost
+}
+
+type BindInnerMost struct {
+	Depth int `stringable:"depth"`
+}
+
+type BindRecursive struct {
+	*BindRecursive
+	Name string `stringable:"name"`
+}
+
+func TestBind_EmbeddedPointer(t *testing.T) {
+	type config struct {
+		*BindInner
+		*url.URL
+		Name string `stringable:"name"`
+	}
+
+	var v config
+	assert.NoError(t, Bind(&v, map[string][]string{"name": {"app"}, "Scheme": {"https"}}))
+	assert.Equal(t, "app", v.Name)
+	assert.Nil(t, v.BindInner, "should not be allocated without its keys")
+	assert.Nil(t, v.URL)
+
+	assert.NoError(t, Bind(&v, map[string][]string{"depth": {"3"}}))
+	assert.NotNil(t, v.BindInner)
+	assert.Equal(t, 3, v.Depth)
+
+	var r BindRecursive
+	assert.NoError(t, Bind(&r, map[string][]string{"name": {"a"}}))
+	assert.Nil(t, r.BindRecursive)
+	assert.Equal(t, "a", r.Name)
+}
@@ -0,0 +1,34 @@
+package stringable
+
+import "github.com/ggicci/stringable/internal"
+
+// BoolOptions configures how bools are converted from/to a string, see
+// WithBool.
+type BoolOptions = internal.BoolOptions
+
+// BoolWords is a pair of words representing true and false.
+type BoolWords = internal.BoolWords
+
+// The common pairs of words representing true and false.
+var (
+	BoolTrueFalse       = BoolWords{True: "true", False: "false"}
+	BoolYesNo           = BoolWords{True: "yes", False: "no"}
+	BoolYN              = BoolWords{True: "y", False: "n"}
+	BoolOnOff           = BoolWords{True: "on", False: "off"}
+	BoolEnabledDisabled = BoolWords{True: "enabled", False: "disabled"}
+	BoolOneZero         = BoolWords{True: "1", False: "0"}
+)
+
+// WithBool sets the options used to convert bool values.
+//
+// Example:
+//
+//	stringable.New(&b, stringable.WithBool(stringable.BoolOptions{
+//		Words:  []stringable.BoolWords{stringable.BoolYesNo, {True: "はい", False: "いいえ"}},
+//		Output: stringable.BoolYesNo,
+//	}))
+func WithBool(o BoolOptions) Option {
+	return func(opts *options) {
+		opts.Bool = &o
+	}
+}
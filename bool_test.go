@@ -0,0 +1,73 @@
+package stringable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithBool(t *testing.T) {
+	opts := WithBool(BoolOptions{
+		Words:  []BoolWords{BoolYesNo, BoolOnOff, {True: "はい", False: "いいえ"}},
+		Output: BoolOnOff,
+	})
+
+	for input, expected := range map[string]bool{
+		"yes": true,
+		"YES": true,
+		"No":  false,
+		"on":  true,
+		"OFF": false,
+		"はい":  true,
+		"いいえ": false,
+	} {
+		v, err := Parse[bool](input, opts)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, v, input)
+	}
+
+	_, err := Parse[bool]("true", opts)
+	assert.ErrorContains(t, err, "accepted values are: yes, no, on, off, はい, いいえ")
+
+	s, err := Format(true, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "on", s)
+	s, err = Format(false, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "off", s)
+}
+
+func TestWithBool_CaseSensitive(t *testing.T) {
+	opts := WithBool(BoolOptions{Words: []BoolWords{BoolYN}, CaseSensitive: true})
+	v, err := Parse[bool]("y", opts)
+	assert.NoError(t, err)
+	assert.True(t, v)
+	_, err = Parse[bool]("Y", opts)
+	assert.Error(t, err)
+}
+
+func TestWithBool_Defaults(t *testing.T) {
+	opts := WithBool(BoolOptions{})
+	v, err := Parse[bool]("T", opts)
+	assert.NoError(t, err)
+	assert.True(t, v)
+
+	s, err := Format(false, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "false", s)
+}
+
+func TestWithBool_Namespace(t *testing.T) {
+	ns := NewNamespace(WithDefaultOptions(WithBool(BoolOptions{
+		Words:  []BoolWords{BoolEnabledDisabled, BoolOneZero},
+		Output: BoolEnabledDisabled,
+	})))
+
+	flags, err := ParseIn[map[string]bool](ns, "cache=enabled,debug=0")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"cache": true, "debug": false}, flags)
+
+	s, err := FormatIn(ns, flags)
+	assert.NoError(t, err)
+	assert.Equal(t, "cache=enabled,debug=disabled", s)
+}
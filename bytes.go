@@ -0,0 +1,49 @@
+package stringable
+
+import (
+	"reflect"
+
+	"github.com/ggicci/stringable/internal"
+)
+
+// BytesEncoding is the encoding used to convert []byte and [N]byte values
+// from/to a string, see WithBytesEncoding.
+type BytesEncoding = internal.BytesEncoding
+
+const (
+	Base64       = internal.Base64       // standard base64, padded
+	Base64Raw    = internal.Base64Raw    // standard base64, unpadded
+	Base64URL    = internal.Base64URL    // URL-safe base64, padded
+	Base64RawURL = internal.Base64RawURL // URL-safe base64, unpadded
+	HexLower     = internal.HexLower     // lowercase hex
+	HexUpper     = internal.HexUpper     // uppercase hex, decodes both cases
+	Base32       = internal.Base32       // standard base32, padded
+	Base32Raw    = internal.Base32Raw    // standard base32, unpadded
+	Base58       = internal.Base58       // base58 with the bitcoin alphabet
+	AutoEncoding = internal.AutoEncoding // detects the encoding while decoding
+)
+
+// WithBytesEncoding sets the encoding used to convert []byte and [N]byte
+// values. By default, []byte values are converted as standard base64, while
+// [N]byte values, e.g. [32]byte SHA-256 digests, are converted as lowercase
+// hex.
+func WithBytesEncoding(enc BytesEncoding) Option {
+	return func(o *options) {
+		o.BytesEncoding = enc
+	}
+}
+
+func isByteArray(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Array && typ.Elem().Kind() == reflect.Uint8
+}
+
+// createByteArrayStringable creates a Stringable for a pointer to a byte
+// array, e.g. *[32]byte.
+func createByteArrayStringable(rv reflect.Value, opts *options) Stringable {
+	enc := opts.BytesEncoding
+	if enc == 0 {
+		enc = HexLower
+	}
+	arr := rv.Elem()
+	return &internal.ByteArray{Value: arr.Slice(0, arr.Len()).Bytes(), Encoding: enc}
+}
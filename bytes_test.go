@@ -0,0 +1,90 @@
+package stringable
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithBytesEncoding(t *testing.T) {
+	data := []byte{0, 0, 0xfb, 0xff, 0x10, 'h', 'i'}
+	for enc, expected := range map[BytesEncoding]string{
+		Base64:       "AAD7/xBoaQ==",
+		Base64Raw:    "AAD7/xBoaQ",
+		Base64URL:    "AAD7_xBoaQ==",
+		Base64RawURL: "AAD7_xBoaQ",
+		HexLower:     "0000fbff106869",
+		HexUpper:     "0000FBFF106869",
+		Base32:       "AAAPX7YQNBUQ====",
+		Base32Raw:    "AAAPX7YQNBUQ",
+		Base58:       "11VRyZhKv",
+		AutoEncoding: "AAD7/xBoaQ==",
+	} {
+		s, err := Format(data, WithBytesEncoding(enc))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, s, enc)
+
+		b, err := Parse[[]byte](expected, WithBytesEncoding(enc))
+		assert.NoError(t, err)
+		assert.Equal(t, data, b, enc)
+	}
+
+	b, err := Parse[[]byte]("0000FBFF106869", WithBytesEncoding(HexLower))
+	assert.NoError(t, err)
+	assert.Equal(t, data, b)
+
+	_, err = Parse[[]byte]("0OIl", WithBytesEncoding(Base58))
+	assert.Error(t, err)
+}
+
+func TestWithBytesEncoding_Auto(t *testing.T) {
+	data := []byte{0, 0, 0xfb, 0xff, 0x10, 'h', 'i'}
+	for _, input := range []string{"0000fbff106869", "AAD7/xBoaQ==", "AAD7_xBoaQ", "AAAPX7YQNBUQ===="} {
+		b, err := Parse[[]byte](input, WithBytesEncoding(AutoEncoding))
+		assert.NoError(t, err, input)
+		assert.Equal(t, data, b, input)
+	}
+
+	_, err := Parse[[]byte]("!!", WithBytesEncoding(AutoEncoding))
+	assert.Error(t, err)
+}
+
+func TestNew_ByteArray(t *testing.T) {
+	digest := sha256.Sum256([]byte("hello"))
+	sb, err := New(&digest)
+	assert.NoError(t, err)
+
+	s, err := sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", s)
+
+	var got [32]byte
+	sb, err = New(&got)
+	assert.NoError(t, err)
+	assert.NoError(t, sb.FromString(s))
+	assert.Equal(t, digest, got)
+
+	assert.Error(t, sb.FromString("2cf24dba"), "length mismatch")
+	assert.Error(t, sb.FromString("hello"))
+
+	s, err = Format([4]byte{1, 2, 3, 4}, WithBytesEncoding(Base64))
+	assert.NoError(t, err)
+	assert.Equal(t, "AQIDBA==", s)
+}
+
+type Digest [4]byte
+type RawBytes []byte
+
+func TestNew_NamedBytes(t *testing.T) {
+	s, err := Format(Digest{0xde, 0xad, 0xbe, 0xef})
+	assert.NoError(t, err)
+	assert.Equal(t, "deadbeef", s)
+
+	s, err = Format(RawBytes("hello"))
+	assert.NoError(t, err)
+	assert.Equal(t, "aGVsbG8=", s)
+
+	_, err = Format(RawBytes("hello"), NoKindFallback())
+	assert.NoError(t, err, "converted as a composite")
+}
@@ -0,0 +1,329 @@
+package stringable
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// createCompositeStringable tries to create a Stringable for the composite
+// types, i.e. slices, arrays and maps, whose elements are resolvable by the
+// same namespace. Returns nil if rv is not a pointer to a composite type.
+func (c *Namespace) createCompositeStringable(rv reflect.Value, opts *options) (Stringable, error) {
+	typ := rv.Type().Elem()
+	switch typ.Kind() {
+	case reflect.Slice, reflect.Array:
+		if opts.Separator == "" {
+			return nil, fmt.Errorf("%w: separator must not be empty", ErrInvalidSeparator)
+		}
+		if err := c.mustResolveElements(typ, opts); err != nil {
+			return nil, err
+		}
+		return &list{ns: c, rv: rv.Elem(), opts: opts}, nil
+	case reflect.Map:
+		if opts.Separator == "" || opts.KeyValueSeparator == "" {
+			return nil, fmt.Errorf("%w: separator must not be empty", ErrInvalidSeparator)
+		}
+		if opts.Separator == opts.KeyValueSeparator {
+			return nil, fmt.Errorf("%w: separator and key/value separator must be different", ErrInvalidSeparator)
+		}
+		if err := c.mustResolveElements(typ, opts); err != nil {
+			return nil, err
+		}
+		return &dict{ns: c, rv: rv.Elem(), opts: opts}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// mustResolveElements fails fast if the element type, or the key type of a
+// map, of the composite type typ is not supported, or if typ is recursive,
+// e.g. type R []R, which can't be converted in finite steps.
+func (c *Namespace) mustResolveElements(typ reflect.Type, opts *options) error {
+	child, err := resolvingOptions(typ, opts)
+	if err != nil {
+		return err
+	}
+
+	if typ.Kind() == reflect.Map {
+		if _, err := c.createStringable(reflect.New(typ.Key()), child); err != nil {
+			return fmt.Errorf("key of %v: %w", typ, err)
+		}
+	}
+	if _, err := c.createStringable(reflect.New(typ.Elem()), child); err != nil {
+		return fmt.Errorf("element of %v: %w", typ, err)
+	}
+	return nil
+}
+
+// resolvingOptions returns a copy of opts with typ appended to the chain of
+// the types being resolved. Returns ErrUnsupportedType if typ is already in
+// the chain, i.e. it's recursive.
+func resolvingOptions(typ reflect.Type, opts *options) (*options, error) {
+	for _, t := range opts.resolving {
+		if t == typ {
+			return nil, fmt.Errorf("%w: recursive type %v", ErrUnsupportedType, typ)
+		}
+	}
+	child := *opts
+	child.resolving = append(opts.resolving[:len(opts.resolving):len(opts.resolving)], typ)
+	return &child, nil
+}
+
+// list is a Stringable of a slice or an array. The elements are joined by
+// the separator specified in the options. An element containing the
+// separator, a backslash or a double quote will be escaped by a backslash.
+// While parsing, a double-quoted section is taken literally, e.g.
+// `"a,b",c` is split into "a,b" and "c".
+type list struct {
+	ns   *Namespace
+	rv   reflect.Value // the addressable slice or array
+	opts *options
+}
+
+func (l *list) ToString() (string, error) {
+	n := l.rv.Len()
+	elements := make([]string, n)
+	for i := 0; i < n; i++ {
+		sb, err := l.ns.createStringable(l.rv.Index(i).Addr(), l.opts)
+		if err != nil {
+			return "", fmt.Errorf("index %d: %w", i, err)
+		}
+		s, err := sb.ToString()
+		if err != nil {
+			return "", fmt.Errorf("index %d: %w", i, err)
+		}
+		elements[i] = escape(s, l.opts.Separator)
+	}
+
+	// A single empty element must be quoted, otherwise it will be parsed
+	// as an empty list.
+	if n == 1 && elements[0] == "" {
+		return `""`, nil
+	}
+	return strings.Join(elements, l.opts.Separator), nil
+}
+
+func (l *list) FromString(s string) error {
+	raws, err := splitRaw(s, l.opts.Separator, -1)
+	if err != nil {
+		return err
+	}
+
+	var target reflect.Value
+	if l.rv.Kind() == reflect.Array {
+		if len(raws) != l.rv.Len() {
+			return fmt.Errorf("expected %d elements, got %d", l.rv.Len(), len(raws))
+		}
+		target = reflect.New(l.rv.Type()).Elem()
+	} else {
+		target = reflect.MakeSlice(l.rv.Type(), len(raws), len(raws))
+	}
+
+	var errs []error
+	for i, raw := range raws {
+		if err := l.elementFromString(target.Index(i), raw); err != nil {
+			errs = append(errs, fmt.Errorf("index %d: %w", i, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	// Only update the value when all the elements are converted successfully.
+	l.rv.Set(target)
+	return nil
+}
+
+func (l *list) elementFromString(elem reflect.Value, raw string) error {
+	s, err := unescape(raw)
+	if err != nil {
+		return err
+	}
+	sb, err := l.ns.createStringable(elem.Addr(), l.opts)
+	if err != nil {
+		return err
+	}
+	return sb.FromString(s)
+}
+
+// dict is a Stringable of a map. The key/value pairs are joined by the
+// separator, and the key and the value of a pair are joined by the key/value
+// separator, e.g. "a=1,b=2". The pairs are sorted by the string form of their
+// keys in ToString, so that the output is deterministic. The escaping and
+// quoting rules are the same as list.
+type dict struct {
+	ns   *Namespace
+	rv   reflect.Value // the addressable map
+	opts *options
+}
+
+func (d *dict) ToString() (string, error) {
+	type pair struct{ key, value string }
+	pairs := make([]pair, 0, d.rv.Len())
+
+	iter := d.rv.MapRange()
+	for iter.Next() {
+		key, err := d.elementToString(iter.Key())
+		if err != nil {
+			return "", fmt.Errorf("key %v: %w", iter.Key(), err)
+		}
+		value, err := d.elementToString(iter.Value())
+		if err != nil {
+			return "", fmt.Errorf("key %q: %w", key, err)
+		}
+		pairs = append(pairs, pair{key, value})
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].key < pairs[j].key })
+
+	seps := []string{d.opts.Separator, d.opts.KeyValueSeparator}
+	elements := make([]string, len(pairs))
+	for i, p := range pairs {
+		elements[i] = escape(p.key, seps...) + d.opts.KeyValueSeparator + escape(p.value, seps...)
+	}
+	return strings.Join(elements, d.opts.Separator), nil
+}
+
+// elementToString converts a key or a value of the map to a string. As map
+// elements are not addressable, it converts a copy of the element.
+func (d *dict) elementToString(elem reflect.Value) (string, error) {
+	cp := reflect.New(elem.Type())
+	cp.Elem().Set(elem)
+	sb, err := d.ns.createStringable(cp, d.opts)
+	if err != nil {
+		return "", err
+	}
+	return sb.ToString()
+}
+
+func (d *dict) FromString(s string) error {
+	raws, err := splitRaw(s, d.opts.Separator, -1)
+	if err != nil {
+		return err
+	}
+
+	typ := d.rv.Type()
+	target := reflect.MakeMapWithSize(typ, len(raws))
+	var errs []error
+	for i, raw := range raws {
+		kv, _ := splitRaw(raw, d.opts.KeyValueSeparator, 2)
+		if len(kv) != 2 {
+			errs = append(errs, fmt.Errorf("pair %d: %w: missing key/value separator %q", i, ErrInvalidSyntax, d.opts.KeyValueSeparator))
+			continue
+		}
+		key, err := d.elementFromString(typ.Key(), kv[0])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("pair %d: key: %w", i, err))
+			continue
+		}
+		if target.MapIndex(key).IsValid() {
+			errs = append(errs, fmt.Errorf("pair %d: duplicate key %q", i, kv[0]))
+			continue
+		}
+		value, err := d.elementFromString(typ.Elem(), kv[1])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("pair %d: key %q: %w", i, kv[0], err))
+			continue
+		}
+		target.SetMapIndex(key, value)
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	// Only update the value when all the pairs are converted successfully.
+	d.rv.Set(target)
+	return nil
+}
+
+func (d *dict) elementFromString(typ reflect.Type, raw string) (reflect.Value, error) {
+	s, err := unescape(raw)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	elem := reflect.New(typ)
+	sb, err := d.ns.createStringable(elem, d.opts)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if err := sb.FromString(s); err != nil {
+		return reflect.Value{}, err
+	}
+	return elem.Elem(), nil
+}
+
+// escape escapes the backslashes, double quotes and the given separators in s
+// by prefixing them with a backslash.
+func escape(s string, seps ...string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' || s[i] == '"' || hasAnyPrefix(s[i:], seps) {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// splitRaw splits s by the unescaped and unquoted occurrences of sep into at
+// most n parts (n < 0 means all parts). The escapes and quotes are kept in
+// the parts, call unescape to remove them. An empty string results in no
+// parts.
+func splitRaw(s, sep string, n int) ([]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	var (
+		parts   []string
+		start   int
+		inQuote bool
+	)
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\':
+			i++ // skip the escaped byte
+		case s[i] == '"':
+			inQuote = !inQuote
+		case !inQuote && (n < 0 || len(parts) < n-1) && strings.HasPrefix(s[i:], sep):
+			parts = append(parts, s[start:i])
+			start = i + len(sep)
+			i = start - 1
+		}
+	}
+	if inQuote {
+		return nil, fmt.Errorf("%w: unterminated quote in %q", ErrInvalidSyntax, s)
+	}
+	return append(parts, s[start:]), nil
+}
+
+// unescape removes the escapes and quotes from a part produced by splitRaw.
+func unescape(raw string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(raw); i++ {
+		switch raw[i] {
+		case '\\':
+			if i+1 == len(raw) {
+				return "", fmt.Errorf("%w: dangling escape in %q", ErrInvalidSyntax, raw)
+			}
+			i++
+			b.WriteByte(raw[i])
+		case '"':
+			// quotes are not part of the value
+		default:
+			b.WriteByte(raw[i])
+		}
+	}
+	return b.String(), nil
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if prefix != "" && strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
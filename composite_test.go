@@ -0,0 +1,216 @@
+package stringable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_IntSlice(t *testing.T) {
+	var ids []int
+	sb, err := New(&ids)
+	assert.NoError(t, err)
+
+	assert.NoError(t, sb.FromString("1,2,3"))
+	assert.Equal(t, []int{1, 2, 3}, ids)
+	got, err := sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "1,2,3", got)
+
+	assert.NoError(t, sb.FromString(""))
+	assert.Empty(t, ids)
+	got, err = sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "", got)
+}
+
+func TestNew_SliceElementError(t *testing.T) {
+	var ids = []int{7}
+	sb, err := New(&ids)
+	assert.NoError(t, err)
+
+	err = sb.FromString("1,x,3,y")
+	assert.ErrorContains(t, err, "index 1:")
+	assert.ErrorContains(t, err, "index 3:")
+	assert.Equal(t, []int{7}, ids, "value should not change on error")
+}
+
+func TestNew_StringArray(t *testing.T) {
+	var tags [3]string
+	sb, err := New(&tags)
+	assert.NoError(t, err)
+
+	assert.NoError(t, sb.FromString("a,b,c"))
+	assert.Equal(t, [3]string{"a", "b", "c"}, tags)
+
+	assert.ErrorContains(t, sb.FromString("a,b"), "expected 3 elements, got 2")
+	assert.Equal(t, [3]string{"a", "b", "c"}, tags)
+}
+
+func TestNew_SliceWithSeparator(t *testing.T) {
+	var tags []string
+	sb, err := New(&tags, Separator(";"))
+	assert.NoError(t, err)
+
+	assert.NoError(t, sb.FromString("a,b;c"))
+	assert.Equal(t, []string{"a,b", "c"}, tags)
+
+	_, err = New(&tags, Separator(""))
+	assert.ErrorIs(t, err, ErrInvalidSeparator)
+}
+
+func TestNew_SliceEscaping(t *testing.T) {
+	var tags []string
+	sb, err := New(&tags)
+	assert.NoError(t, err)
+
+	for _, c := range []struct {
+		value []string
+		text  string
+	}{
+		{[]string{"a,b", "c"}, `a\,b,c`},
+		{[]string{`back\slash`, `"quoted"`}, `back\\slash,\"quoted\"`},
+		{[]string{""}, `""`},
+		{[]string{"", ""}, `,`},
+	} {
+		tags = c.value
+		got, err := sb.ToString()
+		assert.NoError(t, err)
+		assert.Equal(t, c.text, got)
+
+		tags = nil
+		assert.NoError(t, sb.FromString(c.text))
+		assert.Equal(t, c.value, tags)
+	}
+
+	assert.NoError(t, sb.FromString(`"a,b",c`))
+	assert.Equal(t, []string{"a,b", "c"}, tags)
+
+	assert.ErrorIs(t, sb.FromString(`"a,b`), ErrInvalidSyntax)
+	assert.ErrorIs(t, sb.FromString(`a\`), ErrInvalidSyntax)
+}
+
+func TestNew_NestedSlice(t *testing.T) {
+	var matrix = [][]int{{1, 2}, {3, 4}}
+	sb, err := New(&matrix)
+	assert.NoError(t, err)
+
+	got, err := sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, `1\,2,3\,4`, got)
+
+	assert.NoError(t, sb.FromString(`5\,6,7`))
+	assert.Equal(t, [][]int{{5, 6}, {7}}, matrix)
+}
+
+type RecursiveSlice []RecursiveSlice
+
+type RecursiveMap map[string]RecursiveMap
+
+type RecursivePointerSlice []*RecursivePointerSlice
+
+type RecursivePointer *RecursivePointer
+
+func TestNew_SliceOfUnsupportedType(t *testing.T) {
+	var objects = []StructNotStringable{{}}
+	_, err := New(&objects)
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+	assert.ErrorContains(t, err, "element of []stringable.StructNotStringable:")
+
+	_, err = New(&[]chan int{})
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+	_, err = New(&[]any{})
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+	_, err = New(&[][]any{})
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+	_, err = New(&[2]func(){})
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+}
+
+func TestNew_RecursiveComposite(t *testing.T) {
+	var p RecursivePointer
+	for _, v := range []any{&RecursiveSlice{}, &RecursiveMap{}, &RecursivePointerSlice{}, &p} {
+		_, err := New(v)
+		assert.ErrorIs(t, err, ErrUnsupportedType)
+		assert.ErrorContains(t, err, "recursive type")
+	}
+}
+
+func TestNew_SliceOfHybrid(t *testing.T) {
+	var oranges []TextMarshalerAndUnmarshalerOrange
+	sb, err := New(&oranges)
+	assert.NoError(t, err)
+
+	assert.NoError(t, sb.FromString("navel,blood"))
+	assert.Equal(t, []TextMarshalerAndUnmarshalerOrange{{"navel"}, {"blood"}}, oranges)
+}
+
+func TestNew_Map(t *testing.T) {
+	var labels map[string]int
+	sb, err := New(&labels)
+	assert.NoError(t, err)
+
+	assert.NoError(t, sb.FromString("b=2,a=1,c=3"))
+	assert.Equal(t, map[string]int{"a": 1, "b": 2, "c": 3}, labels)
+	got, err := sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "a=1,b=2,c=3", got)
+
+	assert.NoError(t, sb.FromString(""))
+	assert.Empty(t, labels)
+}
+
+func TestNew_MapSortedByStringForm(t *testing.T) {
+	var m = map[int]bool{10: true, 9: false, 100: true}
+	sb, err := New(&m)
+	assert.NoError(t, err)
+
+	got, err := sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "10=true,100=true,9=false", got)
+}
+
+func TestNew_MapWithSeparators(t *testing.T) {
+	var annotations = map[string]string{"a=b": "c;d", "e": "f"}
+	sb, err := New(&annotations, Separator(";"), KeyValueSeparator(":"))
+	assert.NoError(t, err)
+
+	got, err := sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, `a=b:c\;d;e:f`, got)
+
+	annotations = nil
+	assert.NoError(t, sb.FromString(`a=b:c\;d;e:f;"g:h":i`))
+	assert.Equal(t, map[string]string{"a=b": "c;d", "e": "f", "g:h": "i"}, annotations)
+
+	_, err = New(&annotations, KeyValueSeparator(","))
+	assert.ErrorIs(t, err, ErrInvalidSeparator)
+	_, err = New(&annotations, KeyValueSeparator(""))
+	assert.ErrorIs(t, err, ErrInvalidSeparator)
+}
+
+func TestNew_MapErrors(t *testing.T) {
+	var m = map[string]int{"x": 0}
+	sb, err := New(&m)
+	assert.NoError(t, err)
+
+	err = sb.FromString("a=1,b,c=x,1=2,a=3")
+	assert.ErrorIs(t, err, ErrInvalidSyntax)
+	assert.ErrorContains(t, err, "pair 1: invalid syntax: missing key/value separator")
+	assert.ErrorContains(t, err, `pair 2: key "c":`)
+	assert.ErrorContains(t, err, `pair 4: duplicate key "a"`)
+	assert.Equal(t, map[string]int{"x": 0}, m, "value should not change on error")
+
+	var invalidKeys = map[StructNotStringable]int{{}: 1}
+	_, err = New(&invalidKeys)
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+	assert.ErrorContains(t, err, "key of map[stringable.StructNotStringable]int:")
+
+	var invalidValues = map[string]StructNotStringable{"a": {}}
+	_, err = New(&invalidValues)
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+	assert.ErrorContains(t, err, "element of map[string]stringable.StructNotStringable:")
+
+	_, err = New(&map[string]any{})
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+}
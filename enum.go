@@ -0,0 +1,186 @@
+package stringable
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UnknownPolicy is how an enum handles the values and the names that are not
+// registered.
+type UnknownPolicy int
+
+const (
+	// UnknownReject returns an error wrapping ErrUnknownEnum.
+	UnknownReject UnknownPolicy = iota
+
+	// UnknownRaw converts the unknown values by their underlying kinds,
+	// e.g. Status(7) is formatted as "7" and "7" is parsed as Status(7).
+	UnknownRaw
+)
+
+// EnumOption configures an enum of type T registered by RegisterEnum.
+type EnumOption[T comparable] func(*enumOptions[T])
+
+type enumOptions[T comparable] struct {
+	caseSensitive bool
+	unknown       UnknownPolicy
+	aliases       map[string]T
+	fallback      *T
+}
+
+// EnumCaseSensitive makes the names of an enum case-sensitive while parsing.
+// By default, the names are matched case-insensitively.
+func EnumCaseSensitive[T comparable]() EnumOption[T] {
+	return func(o *enumOptions[T]) {
+		o.caseSensitive = true
+	}
+}
+
+// EnumUnknown sets the policy for the unknown values and names, defaults to
+// UnknownReject.
+func EnumUnknown[T comparable](policy UnknownPolicy) EnumOption[T] {
+	return func(o *enumOptions[T]) {
+		o.unknown = policy
+	}
+}
+
+// EnumAliases adds the extra names of the enum values, e.g. the deprecated
+// ones. The aliases are accepted while parsing but never used in formatting.
+func EnumAliases[T comparable](aliases map[string]T) EnumOption[T] {
+	return func(o *enumOptions[T]) {
+		o.aliases = aliases
+	}
+}
+
+// EnumFallback sets the value that the unknown names are parsed into. It
+// takes precedence over the unknown policy while parsing.
+func EnumFallback[T comparable](v T) EnumOption[T] {
+	return func(o *enumOptions[T]) {
+		o.fallback = &v
+	}
+}
+
+// RegisterEnum registers an adaptor for type T in the namespace, which
+// converts the values of T from/to the given names, so that New(&v) converts
+// the enum values without hand-written methods.
+//
+// It panics if a value has more than one name, if two names or aliases are
+// identical (case-insensitively unless EnumCaseSensitive is given), or if the
+// namespace has been frozen.
+//
+// Example:
+//
+//	type Status int
+//
+//	const (
+//		Active Status = iota
+//		Inactive
+//	)
+//
+//	stringable.RegisterEnum(ns, map[string]Status{
+//		"active":   Active,
+//		"inactive": Inactive,
+//	}, stringable.EnumAliases(map[string]Status{"disabled": Inactive}))
+func RegisterEnum[T comparable](ns *Namespace, names map[string]T, opts ...EnumOption[T]) {
+	var o enumOptions[T]
+	for _, opt := range opts {
+		opt(&o)
+	}
+	e := newEnumDef(names, o)
+	Adapt(ns, func(v *T) (Stringable, error) {
+		return &enum[T]{value: v, def: e}, nil
+	})
+}
+
+// enumDef is the definition of an enum shared by its Stringables.
+type enumDef[T comparable] struct {
+	opts       enumOptions[T]
+	values     map[string]T // names and aliases, folded if case-insensitive
+	names      map[T]string
+	validNames []string
+}
+
+func newEnumDef[T comparable](names map[string]T, opts enumOptions[T]) *enumDef[T] {
+	e := &enumDef[T]{
+		opts:   opts,
+		values: make(map[string]T),
+		names:  make(map[T]string, len(names)),
+	}
+	for name, v := range names {
+		if other, ok := e.names[v]; ok {
+			panic(fmt.Sprintf("stringable: enum %v: value %v has two names %q and %q", typeOf[T](), v, other, name))
+		}
+		e.names[v] = name
+		e.addName(name, v)
+		e.validNames = append(e.validNames, name)
+	}
+	sort.Strings(e.validNames)
+
+	for name, v := range opts.aliases {
+		e.addName(name, v)
+	}
+	return e
+}
+
+func (e *enumDef[T]) addName(name string, v T) {
+	key := e.key(name)
+	if _, ok := e.values[key]; ok {
+		panic(fmt.Sprintf("stringable: enum %v: duplicate name %q", typeOf[T](), name))
+	}
+	e.values[key] = v
+}
+
+func (e *enumDef[T]) key(name string) string {
+	if e.opts.caseSensitive {
+		return name
+	}
+	return strings.ToLower(name)
+}
+
+// enum is the Stringable of an enum value.
+type enum[T comparable] struct {
+	value *T
+	def   *enumDef[T]
+}
+
+func (e *enum[T]) ToString() (string, error) {
+	if name, ok := e.def.names[*e.value]; ok {
+		return name, nil
+	}
+	if e.def.opts.unknown == UnknownRaw {
+		if sb, err := underlyingStringable(e.value); err == nil {
+			return sb.ToString()
+		}
+	}
+	return "", fmt.Errorf("%w value %v", ErrUnknownEnum, *e.value)
+}
+
+func (e *enum[T]) FromString(s string) error {
+	if v, ok := e.def.values[e.def.key(s)]; ok {
+		*e.value = v
+		return nil
+	}
+	if e.def.opts.fallback != nil {
+		*e.value = *e.def.opts.fallback
+		return nil
+	}
+	if e.def.opts.unknown == UnknownRaw {
+		if sb, err := underlyingStringable(e.value); err == nil && sb.FromString(s) == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w name %q, valid names: %s", ErrUnknownEnum, s, strings.Join(e.def.validNames, ", "))
+}
+
+// underlyingStringable creates a Stringable of v by the builtin adaptor of
+// its underlying kind, e.g. int for type Status int.
+func underlyingStringable[T any](v *T) (Stringable, error) {
+	rv := reflect.ValueOf(v)
+	builtinType, ok := kindFallbackTypes[rv.Elem().Kind()]
+	if !ok {
+		return nil, unsupportedType(rv.Type())
+	}
+	return builtinStringableAdaptors[builtinType](rv.Convert(reflect.PointerTo(builtinType)).Interface(), defaultOptions())
+}
@@ -0,0 +1,108 @@
+package stringable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Status int
+
+const (
+	StatusActive Status = iota
+	StatusInactive
+	StatusBanned
+)
+
+var statusNames = map[string]Status{
+	"active":   StatusActive,
+	"inactive": StatusInactive,
+	"banned":   StatusBanned,
+}
+
+func TestRegisterEnum(t *testing.T) {
+	ns := NewNamespace()
+	RegisterEnum(ns, statusNames, EnumAliases(map[string]Status{"disabled": StatusInactive}))
+
+	var status Status
+	sb, err := ns.New(&status)
+	assert.NoError(t, err)
+
+	assert.NoError(t, sb.FromString("Banned"))
+	assert.Equal(t, StatusBanned, status)
+	s, err := sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "banned", s)
+
+	assert.NoError(t, sb.FromString("DISABLED"))
+	assert.Equal(t, StatusInactive, status)
+	s, err = sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "inactive", s, "aliases should never be formatted")
+
+	err = sb.FromString("deleted")
+	assert.ErrorIs(t, err, ErrUnknownEnum)
+	assert.ErrorContains(t, err, `unknown enum name "deleted", valid names: active, banned, inactive`)
+	assert.Equal(t, StatusInactive, status, "value should not change on error")
+
+	status = Status(7)
+	_, err = sb.ToString()
+	assert.ErrorIs(t, err, ErrUnknownEnum)
+	assert.ErrorContains(t, err, "unknown enum value 7")
+
+	statuses, err := ParseIn[[]Status](ns, "active,banned")
+	assert.NoError(t, err)
+	assert.Equal(t, []Status{StatusActive, StatusBanned}, statuses)
+}
+
+func TestRegisterEnum_CaseSensitive(t *testing.T) {
+	ns := NewNamespace()
+	RegisterEnum(ns, statusNames, EnumCaseSensitive[Status]())
+
+	_, err := ParseIn[Status](ns, "Active")
+	assert.ErrorIs(t, err, ErrUnknownEnum)
+	v, err := ParseIn[Status](ns, "active")
+	assert.NoError(t, err)
+	assert.Equal(t, StatusActive, v)
+}
+
+func TestRegisterEnum_Unknown(t *testing.T) {
+	ns := NewNamespace()
+	RegisterEnum(ns, statusNames, EnumUnknown[Status](UnknownRaw))
+
+	s, err := FormatIn(ns, Status(7))
+	assert.NoError(t, err)
+	assert.Equal(t, "7", s)
+	v, err := ParseIn[Status](ns, "7")
+	assert.NoError(t, err)
+	assert.Equal(t, Status(7), v)
+	_, err = ParseIn[Status](ns, "deleted")
+	assert.ErrorIs(t, err, ErrUnknownEnum)
+
+	ns = NewNamespace()
+	RegisterEnum(ns, statusNames, EnumFallback(StatusInactive))
+	v, err = ParseIn[Status](ns, "deleted")
+	assert.NoError(t, err)
+	assert.Equal(t, StatusInactive, v)
+}
+
+func TestRegisterEnum_Panics(t *testing.T) {
+	ns := NewNamespace()
+	assert.Panics(t, func() {
+		RegisterEnum(ns, map[string]Status{"active": StatusActive, "Active": StatusInactive})
+	})
+	assert.Panics(t, func() {
+		RegisterEnum(ns, map[string]Status{"active": StatusActive, "enabled": StatusActive})
+	})
+	assert.Panics(t, func() {
+		RegisterEnum(ns, statusNames, EnumAliases(map[string]Status{"Active": StatusActive}))
+	})
+	assert.NotPanics(t, func() {
+		RegisterEnum(ns, map[string]Status{"active": StatusActive, "Active": StatusInactive}, EnumCaseSensitive[Status]())
+	})
+
+	ns.Freeze()
+	assert.PanicsWithValue(t, ErrFrozenNamespace, func() {
+		RegisterEnum(ns, statusNames)
+	})
+}
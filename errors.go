@@ -1,6 +1,11 @@
 package stringable
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"unicode/utf8"
+)
 
 var (
 	ErrUnsupportedType      = errors.New("unsupported type")
@@ -9,4 +14,109 @@ var (
 	ErrNotStringUnmarshaler = errors.New("not a StringUnmarshaler")
 	ErrNotPointer           = errors.New("not a pointer")
 	ErrNilPointer           = errors.New("nil pointer")
+	ErrInvalidSeparator     = errors.New("invalid separator")
+	ErrInvalidSyntax        = errors.New("invalid syntax")
+	ErrFrozenNamespace      = errors.New("namespace is frozen")
+	ErrUnknownEnum          = errors.New("unknown enum")
+	ErrInvalidTag           = errors.New("invalid struct tag")
+	ErrInvalidOption        = errors.New("invalid option")
 )
+
+// ConversionError is the error returned by the Stringables created by
+// Namespace.New when a conversion fails. Use errors.As to retrieve it, the
+// cause is still matchable by errors.Is, e.g. ErrNotStringUnmarshaler.
+type ConversionError struct {
+	Type      reflect.Type // the type being converted
+	Input     string       // the input of FromString, truncated if too long
+	Direction Direction
+	Path      Path // how the Stringable was created
+	Err       error
+}
+
+func (e *ConversionError) Error() string {
+	if e.Direction == DirectionFromString {
+		return fmt.Sprintf("cannot convert %q to %v (%v): %v", e.Input, e.Type, e.Path, e.Err)
+	}
+	return fmt.Sprintf("cannot convert %v to string (%v): %v", e.Type, e.Path, e.Err)
+}
+
+func (e *ConversionError) Unwrap() error {
+	return e.Err
+}
+
+// FieldError is the error of a struct field returned by Namespace.Bind and
+// Namespace.Unbind. The errors of all the failed fields are joined by
+// errors.Join, use errors.As to retrieve the first one.
+type FieldError struct {
+	Field string // the key of the field, e.g. "db.port"
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("field %q: %v", e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Direction is the direction of a conversion.
+type Direction int
+
+const (
+	DirectionToString   Direction = iota // from a value to a string, i.e. ToString
+	DirectionFromString                  // from a string to a value, i.e. FromString
+)
+
+func (d Direction) String() string {
+	if d == DirectionFromString {
+		return "FromString"
+	}
+	return "ToString"
+}
+
+// Path is the approach used by Namespace.New to create a Stringable. See
+// Namespace.New for the details of each approach.
+type Path int
+
+const (
+	PathAdaptor   Path = iota + 1 // a custom adaptor registered in a namespace
+	PathBuiltin                   // a builtin adaptor
+	PathKind                      // a builtin adaptor of the underlying kind
+	PathPointer                   // a pointer to a pointer
+	PathHybrid                    // a hybrid instance
+	PathComposite                 // a slice, an array or a map
+)
+
+func (p Path) String() string {
+	switch p {
+	case PathAdaptor:
+		return "adaptor"
+	case PathBuiltin:
+		return "builtin"
+	case PathKind:
+		return "kind"
+	case PathPointer:
+		return "pointer"
+	case PathHybrid:
+		return "hybrid"
+	case PathComposite:
+		return "composite"
+	default:
+		return "unknown"
+	}
+}
+
+const maxErrorInputLength = 64
+
+// truncate truncates s to at most n bytes without breaking a UTF-8 character,
+// and appends an ellipsis to it if truncated.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + "..."
+}
@@ -0,0 +1,102 @@
+package stringable
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConversionError_FromString(t *testing.T) {
+	var i int
+	sb, err := New(&i)
+	assert.NoError(t, err)
+
+	err = sb.FromString("hello")
+	var ce *ConversionError
+	assert.True(t, errors.As(err, &ce))
+	assert.Equal(t, typeOf[int](), ce.Type)
+	assert.Equal(t, "hello", ce.Input)
+	assert.Equal(t, DirectionFromString, ce.Direction)
+	assert.Equal(t, PathBuiltin, ce.Path)
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+	assert.ErrorContains(t, err, `cannot convert "hello" to int (builtin): `)
+}
+
+func TestConversionError_ToString(t *testing.T) {
+	watermelon := &TextMarshalerSpoiledWatermelon{}
+	sb, err := New(&watermelon)
+	assert.NoError(t, err)
+
+	_, err = sb.ToString()
+	var ce *ConversionError
+	assert.True(t, errors.As(err, &ce))
+	assert.Equal(t, typeOf[TextMarshalerSpoiledWatermelon](), ce.Type)
+	assert.Equal(t, DirectionToString, ce.Direction)
+	assert.Equal(t, PathHybrid, ce.Path)
+	assert.EqualError(t, err, "cannot convert stringable.TextMarshalerSpoiledWatermelon to string (hybrid): spoiled")
+}
+
+func TestConversionError_SentinelErrors(t *testing.T) {
+	apple := &TextMarshalerApple{}
+	sb, err := New(&apple)
+	assert.NoError(t, err)
+
+	err = sb.FromString("red apple")
+	assert.ErrorIs(t, err, ErrNotStringUnmarshaler)
+	var ce *ConversionError
+	assert.True(t, errors.As(err, &ce))
+	assert.Equal(t, PathHybrid, ce.Path)
+}
+
+func TestConversionError_Paths(t *testing.T) {
+	ns := NewNamespace()
+	Adapt(ns, func(b *bool) (Stringable, error) { return (*YesNo)(b), nil })
+
+	var (
+		b    bool
+		port Port
+		ids  []int
+		pi   *int
+	)
+	for _, c := range []struct {
+		v    any
+		path Path
+	}{
+		{&b, PathAdaptor},
+		{&port, PathKind},
+		{&ids, PathComposite},
+		{&pi, PathBuiltin}, // the error of the value being pointed to
+	} {
+		sb, err := ns.New(c.v)
+		assert.NoError(t, err)
+		var ce *ConversionError
+		assert.True(t, errors.As(sb.FromString("hello"), &ce))
+		assert.Equal(t, c.path, ce.Path)
+	}
+}
+
+func TestConversionError_TruncatesInput(t *testing.T) {
+	var i int
+	sb, err := New(&i)
+	assert.NoError(t, err)
+
+	input := strings.Repeat("a", 63) + "世界"
+	var ce *ConversionError
+	assert.True(t, errors.As(sb.FromString(input), &ce))
+	assert.Equal(t, strings.Repeat("a", 63)+"...", ce.Input)
+}
+
+func TestPath_String(t *testing.T) {
+	assert.Equal(t, "adaptor", PathAdaptor.String())
+	assert.Equal(t, "builtin", PathBuiltin.String())
+	assert.Equal(t, "kind", PathKind.String())
+	assert.Equal(t, "pointer", PathPointer.String())
+	assert.Equal(t, "hybrid", PathHybrid.String())
+	assert.Equal(t, "composite", PathComposite.String())
+	assert.Equal(t, "unknown", Path(0).String())
+	assert.Equal(t, "ToString", DirectionToString.String())
+	assert.Equal(t, "FromString", DirectionFromString.String())
+}
@@ -0,0 +1,174 @@
+package stringable
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ggicci/stringable/internal"
+)
+
+// Integer is the constraint of the integer types, including the named ones.
+type Integer = internal.Integer
+
+// FlagsOption configures the flags of type T registered by RegisterFlags.
+type FlagsOption[T Integer] func(*flagsOptions[T])
+
+type flagsOptions[T Integer] struct {
+	enum      enumOptions[T]
+	separator string
+}
+
+// FlagsSeparator sets the separator between the flag names, defaults to "|".
+func FlagsSeparator[T Integer](sep string) FlagsOption[T] {
+	return func(o *flagsOptions[T]) {
+		o.separator = sep
+	}
+}
+
+// FlagsCaseSensitive makes the flag names case-sensitive while parsing. By
+// default, the names are matched case-insensitively.
+func FlagsCaseSensitive[T Integer]() FlagsOption[T] {
+	return func(o *flagsOptions[T]) {
+		o.enum.caseSensitive = true
+	}
+}
+
+// FlagsAliases adds the extra names of the flags, which can name several
+// bits, e.g. "rw" for read|write. The aliases are accepted while parsing but
+// never used in formatting.
+func FlagsAliases[T Integer](aliases map[string]T) FlagsOption[T] {
+	return func(o *flagsOptions[T]) {
+		o.enum.aliases = aliases
+	}
+}
+
+// RegisterFlags registers an adaptor for the bitmask type T in the
+// namespace, which converts the values of T from/to the names of the set
+// bits joined by a separator, e.g. "read|write". The names are formatted in
+// the order of their bits, and the unknown bits are formatted in hex, e.g.
+// "read|0x30". The hex form is also accepted while parsing. The zero value is
+// converted from/to an empty string.
+//
+// It panics if a name doesn't have exactly one bit, if an alias is zero, in
+// the same cases as RegisterEnum, or if the separator is empty.
+//
+// Example:
+//
+//	type Perm uint8
+//
+//	const (
+//		Read Perm = 1 << iota
+//		Write
+//		Exec
+//	)
+//
+//	stringable.RegisterFlags(ns, map[string]Perm{
+//		"read":  Read,
+//		"write": Write,
+//		"exec":  Exec,
+//	})
+func RegisterFlags[T Integer](ns *Namespace, names map[string]T, opts ...FlagsOption[T]) {
+	o := flagsOptions[T]{separator: "|"}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.separator == "" {
+		panic(fmt.Sprintf("stringable: flags %v: empty separator", typeOf[T]()))
+	}
+	e := newEnumDef(names, o.enum)
+	for v, name := range e.names {
+		if bits.OnesCount64(flagBits(v)) != 1 {
+			panic(fmt.Sprintf("stringable: flags %v: %q is not a single bit", typeOf[T](), name))
+		}
+	}
+	for name, v := range e.values {
+		if v == 0 {
+			panic(fmt.Sprintf("stringable: flags %v: %q is zero", typeOf[T](), name))
+		}
+	}
+
+	bitsInOrder := make([]T, 0, len(e.names))
+	for v := range e.names {
+		bitsInOrder = append(bitsInOrder, v)
+	}
+	sort.Slice(bitsInOrder, func(i, j int) bool { return flagBits(bitsInOrder[i]) < flagBits(bitsInOrder[j]) })
+
+	f := &flagsDef[T]{enumDef: e, bits: bitsInOrder, separator: o.separator}
+	Adapt(ns, func(v *T) (Stringable, error) {
+		return &flags[T]{value: v, def: f}, nil
+	})
+}
+
+// flagBits returns the bits of v within the size of T, i.e. without the sign
+// extension of the negative values.
+func flagBits[T Integer](v T) uint64 {
+	size := typeOf[T]().Bits()
+	if size == 64 {
+		return uint64(v)
+	}
+	return uint64(v) & (1<<size - 1)
+}
+
+type flagsDef[T Integer] struct {
+	*enumDef[T]
+	bits      []T // the named bits in ascending order
+	separator string
+}
+
+// flags is the Stringable of a bitmask value.
+type flags[T Integer] struct {
+	value *T
+	def   *flagsDef[T]
+}
+
+func (f *flags[T]) ToString() (string, error) {
+	var (
+		parts []string
+		rest  = flagBits(*f.value)
+	)
+	for _, bit := range f.def.bits {
+		if rest&flagBits(bit) != 0 {
+			parts = append(parts, f.def.names[bit])
+			rest &^= flagBits(bit)
+		}
+	}
+	if rest != 0 {
+		parts = append(parts, "0x"+strconv.FormatUint(rest, 16))
+	}
+	return strings.Join(parts, f.def.separator), nil
+}
+
+func (f *flags[T]) FromString(s string) error {
+	var v T
+	if s == "" {
+		*f.value = v
+		return nil
+	}
+	for _, part := range strings.Split(s, f.def.separator) {
+		part = strings.TrimSpace(part)
+		if bit, ok := f.def.values[f.def.key(part)]; ok {
+			v |= bit
+			continue
+		}
+		bit, err := f.parseHex(part)
+		if err != nil {
+			return err
+		}
+		v |= bit
+	}
+	*f.value = v
+	return nil
+}
+
+func (f *flags[T]) parseHex(s string) (T, error) {
+	if len(s) > 2 && (s[:2] == "0x" || s[:2] == "0X") {
+		u, err := strconv.ParseUint(s[2:], 16, typeOf[T]().Bits())
+		if err == nil {
+			return T(u), nil
+		}
+	}
+	return 0, fmt.Errorf("%w name %q, valid names: %s", ErrUnknownEnum, s, strings.Join(f.def.validNames, ", "))
+}
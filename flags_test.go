@@ -0,0 +1,113 @@
+package stringable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Perm uint8
+
+const (
+	PermRead Perm = 1 << iota
+	PermWrite
+	PermExec
+)
+
+var permNames = map[string]Perm{
+	"read":  PermRead,
+	"write": PermWrite,
+	"exec":  PermExec,
+}
+
+func TestRegisterFlags(t *testing.T) {
+	ns := NewNamespace()
+	RegisterFlags(ns, permNames, FlagsAliases(map[string]Perm{"rw": PermRead | PermWrite}))
+
+	var perm Perm
+	sb, err := ns.New(&perm)
+	assert.NoError(t, err)
+
+	assert.NoError(t, sb.FromString("exec|READ"))
+	assert.Equal(t, PermRead|PermExec, perm)
+	s, err := sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "read|exec", s)
+
+	assert.NoError(t, sb.FromString("rw | exec"))
+	assert.Equal(t, PermRead|PermWrite|PermExec, perm)
+
+	perm = PermWrite | 0x30
+	s, err = sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "write|0x30", s)
+	assert.NoError(t, sb.FromString(s))
+	assert.Equal(t, PermWrite|0x30, perm)
+
+	err = sb.FromString("read|delete")
+	assert.ErrorIs(t, err, ErrUnknownEnum)
+	assert.ErrorContains(t, err, `unknown enum name "delete", valid names: exec, read, write`)
+	assert.Equal(t, PermWrite|0x30, perm, "value should not change on error")
+	assert.ErrorIs(t, sb.FromString("0x100"), ErrUnknownEnum)
+
+	perm = 0
+	s, err = sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "", s)
+	perm = PermRead
+	assert.NoError(t, sb.FromString(""))
+	assert.Equal(t, Perm(0), perm)
+}
+
+func TestRegisterFlags_Separator(t *testing.T) {
+	ns := NewNamespace()
+	RegisterFlags(ns, permNames, FlagsSeparator[Perm]("+"))
+
+	s, err := FormatIn(ns, PermRead|PermWrite)
+	assert.NoError(t, err)
+	assert.Equal(t, "read+write", s)
+	v, err := ParseIn[Perm](ns, "write+exec")
+	assert.NoError(t, err)
+	assert.Equal(t, PermWrite|PermExec, v)
+}
+
+type SignedFlags int8
+
+func TestRegisterFlags_Signed(t *testing.T) {
+	ns := NewNamespace()
+	RegisterFlags(ns, map[string]SignedFlags{"low": 1, "high": -128})
+
+	s, err := FormatIn(ns, SignedFlags(-127))
+	assert.NoError(t, err)
+	assert.Equal(t, "low|high", s)
+	s, err = FormatIn(ns, SignedFlags(-1))
+	assert.NoError(t, err)
+	assert.Equal(t, "low|high|0x7e", s)
+}
+
+func TestRegisterFlags_Panics(t *testing.T) {
+	ns := NewNamespace()
+	assert.Panics(t, func() {
+		RegisterFlags(ns, map[string]Perm{"rw": PermRead | PermWrite})
+	})
+	assert.Panics(t, func() {
+		RegisterFlags(ns, map[string]Perm{"none": 0})
+	})
+	assert.Panics(t, func() {
+		RegisterFlags(ns, permNames, FlagsAliases(map[string]Perm{"none": 0}))
+	})
+	assert.Panics(t, func() {
+		RegisterFlags(ns, permNames, FlagsSeparator[Perm](""))
+	})
+}
+
+func TestRegisterFlags_CaseSensitive(t *testing.T) {
+	ns := NewNamespace()
+	RegisterFlags(ns, permNames, FlagsCaseSensitive[Perm]())
+
+	_, err := ParseIn[Perm](ns, "READ")
+	assert.ErrorIs(t, err, ErrUnknownEnum)
+	v, err := ParseIn[Perm](ns, "read")
+	assert.NoError(t, err)
+	assert.Equal(t, PermRead, v)
+}
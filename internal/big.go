@@ -0,0 +1,155 @@
+package internal
+
+import (
+	"errors"
+	"math/big"
+)
+
+// BigOptions configures how big.Int, big.Float and big.Rat values are
+// converted from/to a string.
+type BigOptions struct {
+	// IntBase is the base of big.Int values, from 2 to 62. Defaults to 0,
+	// which accepts the Go syntax of integer literals while parsing, e.g.
+	// "0x10", "1_000", and formats in base 10.
+	IntBase int
+
+	// FloatPrec is the precision, in mantissa bits, of the big.Float values
+	// parsed. Defaults to 64.
+	FloatPrec uint
+
+	// FloatMode is the rounding mode of the big.Float values parsed.
+	// Defaults to big.ToNearestEven.
+	FloatMode big.RoundingMode
+
+	// FloatFormat is the format of big.Float values, the same as
+	// FloatOptions.Format. Defaults to "g" with the smallest number of
+	// digits necessary to represent the value exactly.
+	FloatFormat string
+
+	// RatFormat is the format of big.Rat values, either "" for the fraction
+	// form "a/b" ("a" for integers), or ".Nf" for the decimal form rounded to
+	// N digits after the decimal point, e.g. ".2f". Both forms are accepted
+	// while parsing.
+	RatFormat string
+}
+
+var defaultBigOptions = &BigOptions{}
+
+// ValidateBigOptions reports whether the options are valid, i.e. IntBase,
+// FloatFormat and RatFormat are valid.
+func ValidateBigOptions(o BigOptions) error {
+	if o.IntBase == 1 || o.IntBase < 0 || o.IntBase > 62 {
+		return errors.New("invalid base")
+	}
+	if _, _, err := (&FloatOptions{Format: o.FloatFormat}).formatVerb(); err != nil {
+		return err
+	}
+	if o.RatFormat != "" {
+		if _, err := ratPrec(o.RatFormat); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ratPrec returns the number of digits after the decimal point specified by
+// the big.Rat format ".Nf".
+func ratPrec(format string) (int, error) {
+	verb, prec, err := (&FloatOptions{Format: format}).formatVerb()
+	if err != nil || verb != 'f' || prec < 0 {
+		return 0, errors.New("invalid big.Rat format " + format)
+	}
+	return prec, nil
+}
+
+// BigInt is a wrapper of *big.Int to implement Stringable.
+type BigInt struct {
+	Value   *big.Int
+	Options *BigOptions
+}
+
+func (bi *BigInt) ToString() (string, error) {
+	base := bigOptions(bi.Options).IntBase
+	if base == 0 {
+		base = 10
+	}
+	if base < 2 || base > 62 {
+		return "", errors.New("invalid base")
+	}
+	return bi.Value.Text(base), nil
+}
+
+func (bi *BigInt) FromString(s string) error {
+	base := bigOptions(bi.Options).IntBase
+	if base == 1 || base < 0 || base > 62 {
+		return errors.New("invalid base")
+	}
+	v, ok := new(big.Int).SetString(s, base)
+	if !ok {
+		return errors.New("invalid big.Int value")
+	}
+	bi.Value.Set(v)
+	return nil
+}
+
+// BigFloat is a wrapper of *big.Float to implement Stringable.
+type BigFloat struct {
+	Value   *big.Float
+	Options *BigOptions
+}
+
+func (bf *BigFloat) ToString() (string, error) {
+	format := bigOptions(bf.Options).FloatFormat
+	if format == "" {
+		format = "g"
+	}
+	verb, prec, err := (&FloatOptions{Format: format}).formatVerb()
+	if err != nil {
+		return "", err
+	}
+	return bf.Value.Text(verb, prec), nil
+}
+
+func (bf *BigFloat) FromString(s string) error {
+	o := bigOptions(bf.Options)
+	f := new(big.Float).SetPrec(o.FloatPrec).SetMode(o.FloatMode)
+	if _, _, err := f.Parse(s, 0); err != nil {
+		return err
+	}
+	bf.Value.SetMode(f.Mode()).SetPrec(f.Prec()).Set(f)
+	return nil
+}
+
+// BigRat is a wrapper of *big.Rat to implement Stringable.
+type BigRat struct {
+	Value   *big.Rat
+	Options *BigOptions
+}
+
+func (br *BigRat) ToString() (string, error) {
+	format := bigOptions(br.Options).RatFormat
+	if format == "" {
+		return br.Value.RatString(), nil
+	}
+	prec, err := ratPrec(format)
+	if err != nil {
+		return "", err
+	}
+	return br.Value.FloatString(prec), nil
+}
+
+func (br *BigRat) FromString(s string) error {
+	v, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return errors.New("invalid big.Rat value")
+	}
+	br.Value.Set(v)
+	return nil
+}
+
+func bigOptions(o *BigOptions) *BigOptions {
+	if o == nil {
+		return defaultBigOptions
+	}
+	return o
+}
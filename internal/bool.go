@@ -0,0 +1,81 @@
+package internal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BoolWords is a pair of words representing true and false.
+type BoolWords struct {
+	True  string
+	False string
+}
+
+// BoolOptions configures how a bool is converted from/to a string.
+type BoolOptions struct {
+	// Words are the pairs of words accepted while parsing. Defaults to the
+	// words accepted by strconv.ParseBool.
+	Words []BoolWords
+
+	// CaseSensitive makes the matching of Words case-sensitive. It has no
+	// effect if Words is empty.
+	CaseSensitive bool
+
+	// Output is the pair of words used while formatting. Defaults to "true"
+	// and "false".
+	Output BoolWords
+}
+
+func (o *BoolOptions) parse(s string) (bool, error) {
+	if len(o.Words) == 0 {
+		return strconv.ParseBool(s)
+	}
+
+	equal := strings.EqualFold
+	if o.CaseSensitive {
+		equal = func(a, b string) bool { return a == b }
+	}
+	var accepted []string
+	for _, w := range o.Words {
+		if equal(s, w.True) {
+			return true, nil
+		}
+		if equal(s, w.False) {
+			return false, nil
+		}
+		accepted = append(accepted, w.True, w.False)
+	}
+	return false, fmt.Errorf("invalid bool value, accepted values are: %s", strings.Join(accepted, ", "))
+}
+
+func (o *BoolOptions) format(v bool) string {
+	output := o.Output
+	if output == (BoolWords{}) {
+		output = BoolWords{"true", "false"}
+	}
+	if v {
+		return output.True
+	}
+	return output.False
+}
+
+// FormattedBool is a wrapper of *bool to implement Stringable, which is
+// converted with the given options.
+type FormattedBool struct {
+	Value   *bool
+	Options *BoolOptions
+}
+
+func (fb *FormattedBool) ToString() (string, error) {
+	return fb.Options.format(*fb.Value), nil
+}
+
+func (fb *FormattedBool) FromString(s string) error {
+	v, err := fb.Options.parse(s)
+	if err != nil {
+		return err
+	}
+	*fb.Value = v
+	return nil
+}
@@ -260,13 +260,13 @@ func (tv *Time) FromString(s string) error {
 	}
 }
 
-var reUnixtime = regexp.MustCompile(`^\d+(\.\d{1,9})?$`)
+var reUnixtime = regexp.MustCompile(`^-?\d+(\.\d{1,9})?$`)
 
 // DecodeTime parses data bytes as time.Time in UTC timezone.
 // Supported formats of the data bytes are:
 // 1. RFC3339Nano string, e.g. "2006-01-02T15:04:05-07:00".
 // 2. Date string, e.g. "2006-01-02".
-// 3. Unix timestamp, e.g. "1136239445", "1136239445.8", "1136239445.812738".
+// 3. Unix timestamp, e.g. "1136239445", "1136239445.8", "-86400".
 func DecodeTime(value string) (time.Time, error) {
 	// Try parsing value as RFC3339 format.
 	if t, err := time.ParseInLocation(time.RFC3339Nano, value, time.UTC); err == nil {
@@ -287,16 +287,9 @@ func DecodeTime(value string) (time.Time, error) {
 	return time.Time{}, errors.New("invalid time value")
 }
 
-// value must be valid unix timestamp, matches reUnixtime.
+// value must be valid unix timestamp in seconds, matches reUnixtime.
 func DecodeUnixtime(value string) (time.Time, error) {
-	parts := strings.Split(value, ".")
-	// Note: errors are ignored, since we already validated the value.
-	sec, _ := strconv.ParseInt(parts[0], 10, 64)
-	var nsec int64
-	if len(parts) == 2 {
-		nsec, _ = strconv.ParseInt(nanoSecondPrecision(parts[1]), 10, 64)
-	}
-	return time.Unix(sec, nsec).UTC(), nil
+	return DecodeUnixtimeWithPrecision(value, UnixSeconds)
 }
 
 func nanoSecondPrecision(value string) string {
@@ -0,0 +1,167 @@
+package internal
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+)
+
+// BytesEncoding is the encoding used to convert bytes from/to a string.
+type BytesEncoding int
+
+const (
+	Base64       BytesEncoding = iota + 1 // standard base64, padded
+	Base64Raw                             // standard base64, unpadded
+	Base64URL                             // URL-safe base64, padded
+	Base64RawURL                          // URL-safe base64, unpadded
+	HexLower                              // lowercase hex
+	HexUpper                              // uppercase hex
+	Base32                                // standard base32, padded
+	Base32Raw                             // standard base32, unpadded
+	Base58                                // base58 with the bitcoin alphabet
+	// AutoEncoding detects the encoding while decoding, by trying hex,
+	// the base64 variants, the base32 variants and base58 in order, the first
+	// one that decodes wins. Encodes as standard base64.
+	AutoEncoding
+)
+
+// Encode encodes b as a string.
+func (e BytesEncoding) Encode(b []byte) string {
+	switch e {
+	case Base64Raw:
+		return base64.RawStdEncoding.EncodeToString(b)
+	case Base64URL:
+		return base64.URLEncoding.EncodeToString(b)
+	case Base64RawURL:
+		return base64.RawURLEncoding.EncodeToString(b)
+	case HexLower:
+		return hex.EncodeToString(b)
+	case HexUpper:
+		return strings.ToUpper(hex.EncodeToString(b))
+	case Base32:
+		return base32.StdEncoding.EncodeToString(b)
+	case Base32Raw:
+		return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b)
+	case Base58:
+		return encodeBase58(b)
+	default:
+		return base64.StdEncoding.EncodeToString(b)
+	}
+}
+
+// Decode decodes s as bytes.
+func (e BytesEncoding) Decode(s string) ([]byte, error) {
+	switch e {
+	case Base64Raw:
+		return base64.RawStdEncoding.DecodeString(s)
+	case Base64URL:
+		return base64.URLEncoding.DecodeString(s)
+	case Base64RawURL:
+		return base64.RawURLEncoding.DecodeString(s)
+	case HexLower, HexUpper:
+		return hex.DecodeString(s)
+	case Base32:
+		return base32.StdEncoding.DecodeString(s)
+	case Base32Raw:
+		return base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(s)
+	case Base58:
+		return decodeBase58(s)
+	case AutoEncoding:
+		for _, enc := range []BytesEncoding{HexLower, Base64, Base64URL, Base64Raw, Base64RawURL, Base32, Base32Raw, Base58} {
+			if b, err := enc.Decode(s); err == nil {
+				return b, nil
+			}
+		}
+		return nil, errors.New("unknown bytes encoding")
+	default:
+		return base64.StdEncoding.DecodeString(s)
+	}
+}
+
+// EncodedBytes is a wrapper of *[]byte to implement Stringable, which is
+// converted with the given encoding.
+type EncodedBytes struct {
+	Value    *[]byte
+	Encoding BytesEncoding
+}
+
+func (eb *EncodedBytes) ToString() (string, error) {
+	return eb.Encoding.Encode(*eb.Value), nil
+}
+
+func (eb *EncodedBytes) FromString(s string) error {
+	v, err := eb.Encoding.Decode(s)
+	if err != nil {
+		return err
+	}
+	*eb.Value = v
+	return nil
+}
+
+// ByteArray is a wrapper of a fixed-size byte array, e.g. [32]byte, to
+// implement Stringable. Value must share the memory of the array.
+type ByteArray struct {
+	Value    []byte
+	Encoding BytesEncoding
+}
+
+func (ba *ByteArray) ToString() (string, error) {
+	return ba.Encoding.Encode(ba.Value), nil
+}
+
+func (ba *ByteArray) FromString(s string) error {
+	v, err := ba.Encoding.Decode(s)
+	if err != nil {
+		return err
+	}
+	if len(v) != len(ba.Value) {
+		return errors.New("invalid length of bytes")
+	}
+	copy(ba.Value, v)
+	return nil
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var bigRadix58 = big.NewInt(58)
+
+func encodeBase58(b []byte) string {
+	var out []byte
+	n := new(big.Int).SetBytes(b)
+	mod := new(big.Int)
+	for n.Sign() > 0 {
+		n.DivMod(n, bigRadix58, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+	// Each leading zero byte is encoded as a leading '1'.
+	for _, c := range b {
+		if c != 0 {
+			break
+		}
+		out = append(out, base58Alphabet[0])
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+func decodeBase58(s string) ([]byte, error) {
+	n := new(big.Int)
+	for i := 0; i < len(s); i++ {
+		d := strings.IndexByte(base58Alphabet, s[i])
+		if d < 0 {
+			return nil, errors.New("illegal base58 data")
+		}
+		n.Mul(n, bigRadix58)
+		n.Add(n, big.NewInt(int64(d)))
+	}
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
@@ -0,0 +1,155 @@
+package internal
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Duration is a wrapper of time.Duration to implement Stringable.
+// Supported formats of the input are:
+// 1. time.ParseDuration syntax, e.g. "1h30m", "-1.5s".
+// 2. time.ParseDuration syntax plus days and weeks, e.g. "3d12h", "2w".
+// 3. ISO 8601 durations without years and months, e.g. "PT1H30M", "P1DT12H".
+// The output is always in the time.Duration.String form, e.g. "1h30m0s".
+type Duration time.Duration
+
+func (dv Duration) ToString() (string, error) {
+	return time.Duration(dv).String(), nil
+}
+
+func (dv *Duration) FromString(s string) error {
+	if d, err := DecodeDuration(s); err != nil {
+		return err
+	} else {
+		*dv = Duration(d)
+		return nil
+	}
+}
+
+var (
+	errInvalidDuration  = errors.New("invalid duration value")
+	errDurationOverflow = errors.New("duration out of range")
+)
+
+// DecodeDuration parses a duration string. See Duration for the supported
+// formats.
+func DecodeDuration(value string) (time.Duration, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return d, nil
+	}
+	if strings.ContainsRune(value, 'P') {
+		return decodeISO8601Duration(value)
+	}
+	return decodeExtendedDuration(value)
+}
+
+// decodeExtendedDuration parses a duration string in time.ParseDuration
+// syntax, with the extra units "d" (24h) and "w" (7d).
+func decodeExtendedDuration(value string) (time.Duration, error) {
+	s, neg := trimSign(value)
+	if s == "" {
+		return 0, errInvalidDuration
+	}
+
+	var (
+		total time.Duration
+		rest  strings.Builder // the parts that time.ParseDuration understands
+	)
+	for s != "" {
+		i := strings.IndexFunc(s, func(r rune) bool { return !isDecimal(r) })
+		if i <= 0 {
+			return 0, errInvalidDuration // missing number or unit
+		}
+		num, unit := s[:i], s[i:]
+		if j := strings.IndexFunc(unit, isDecimal); j >= 0 {
+			unit, s = unit[:j], unit[j:]
+		} else {
+			s = ""
+		}
+
+		var hours int64
+		switch unit {
+		case "w":
+			hours = 7 * 24
+		case "d":
+			hours = 24
+		default:
+			rest.WriteString(num + unit)
+			continue
+		}
+		d, err := time.ParseDuration(num + "h")
+		if err != nil {
+			return 0, errInvalidDuration
+		}
+		if d > math.MaxInt64/time.Duration(hours) {
+			return 0, errDurationOverflow
+		}
+		if total, err = addDuration(total, d*time.Duration(hours)); err != nil {
+			return 0, err
+		}
+	}
+
+	if rest.Len() > 0 {
+		d, err := time.ParseDuration(rest.String())
+		if err != nil {
+			return 0, errInvalidDuration
+		}
+		if total, err = addDuration(total, d); err != nil {
+			return 0, err
+		}
+	}
+
+	if neg {
+		total = -total
+	}
+	return total, nil
+}
+
+var reISO8601Duration = regexp.MustCompile(`^P(?:(\d+(?:[.,]\d+)?)W)?(?:(\d+(?:[.,]\d+)?)D)?(?:T(?:(\d+(?:[.,]\d+)?)H)?(?:(\d+(?:[.,]\d+)?)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+
+// decodeISO8601Duration parses an ISO 8601 duration string, e.g. "PT1H30M".
+// Years and months are not supported since their lengths vary.
+func decodeISO8601Duration(value string) (time.Duration, error) {
+	s, neg := trimSign(value)
+	matches := reISO8601Duration.FindStringSubmatch(s)
+	if matches == nil || strings.HasSuffix(s, "T") {
+		return 0, errInvalidDuration
+	}
+
+	var b strings.Builder
+	for i, unit := range []string{"w", "d", "h", "m", "s"} {
+		if num := matches[i+1]; num != "" {
+			b.WriteString(strings.Replace(num, ",", ".", 1) + unit)
+		}
+	}
+	if b.Len() == 0 {
+		return 0, errInvalidDuration
+	}
+
+	d, err := decodeExtendedDuration(b.String())
+	if neg {
+		d = -d
+	}
+	return d, err
+}
+
+func trimSign(s string) (string, bool) {
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		return s[1:], s[0] == '-'
+	}
+	return s, false
+}
+
+func isDecimal(r rune) bool {
+	return r == '.' || ('0' <= r && r <= '9')
+}
+
+func addDuration(a, b time.Duration) (time.Duration, error) {
+	if a > math.MaxInt64-b {
+		return 0, errDurationOverflow
+	}
+	return a + b, nil
+}
@@ -0,0 +1,127 @@
+package internal
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// Float is the constraint of the floating-point types supported by
+// FormattedFloat.
+type Float interface {
+	~float32 | ~float64
+}
+
+// FloatOptions configures how a floating-point number is converted from/to
+// a string.
+type FloatOptions struct {
+	// Format is the format used while formatting, an optional precision
+	// followed by a verb of strconv.FormatFloat, i.e. "f", "e", "E", "g" or
+	// "G". e.g. "e", ".2f", ".3e". Defaults to "f" with the smallest number
+	// of digits necessary to represent the value exactly.
+	Format string
+
+	// RejectNaN rejects NaN values in both directions.
+	RejectNaN bool
+
+	// RejectInf rejects ±Inf values in both directions.
+	RejectInf bool
+
+	// RejectPrecisionLoss rejects the input that can't be represented by a
+	// float32 without losing precision, e.g. "3.14159265358979". It has no
+	// effect on float64 values.
+	RejectPrecisionLoss bool
+}
+
+var (
+	errNaN           = errors.New("NaN is not allowed")
+	errInf           = errors.New("Inf is not allowed")
+	errPrecisionLoss = errors.New("value loses precision")
+)
+
+func (o *FloatOptions) check(v float64) error {
+	if o.RejectNaN && math.IsNaN(v) {
+		return errNaN
+	}
+	if o.RejectInf && math.IsInf(v, 0) {
+		return errInf
+	}
+	return nil
+}
+
+// ValidateFloatOptions reports whether the options are valid, i.e. Format is
+// a valid format.
+func ValidateFloatOptions(o FloatOptions) error {
+	_, _, err := o.formatVerb()
+	return err
+}
+
+// formatVerb returns the verb and the precision specified by Format.
+func (o *FloatOptions) formatVerb() (byte, int, error) {
+	if o.Format == "" {
+		return 'f', -1, nil
+	}
+	invalid := errors.New("invalid float format " + strconv.Quote(o.Format))
+	verb := o.Format[len(o.Format)-1]
+	if !strings.ContainsRune("feEgG", rune(verb)) {
+		return 0, 0, invalid
+	}
+	prec := -1
+	if spec := o.Format[:len(o.Format)-1]; spec != "" {
+		if spec[0] != '.' {
+			return 0, 0, invalid
+		}
+		var err error
+		if prec, err = strconv.Atoi(spec[1:]); err != nil || prec < 0 {
+			return 0, 0, invalid
+		}
+	}
+	return verb, prec, nil
+}
+
+// FormattedFloat is a wrapper of a pointer to a floating-point number to
+// implement Stringable, which is converted with the given options.
+type FormattedFloat[T Float] struct {
+	Value   *T
+	Options *FloatOptions
+}
+
+func (ff *FormattedFloat[T]) ToString() (string, error) {
+	v := float64(*ff.Value)
+	if err := ff.Options.check(v); err != nil {
+		return "", err
+	}
+	verb, prec, err := ff.Options.formatVerb()
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatFloat(v, verb, prec, ff.bitSize()), nil
+}
+
+func (ff *FormattedFloat[T]) FromString(s string) error {
+	bitSize := ff.bitSize()
+	v, err := strconv.ParseFloat(s, bitSize)
+	if err != nil {
+		return err
+	}
+	if err := ff.Options.check(v); err != nil {
+		return err
+	}
+	if bitSize == 32 && ff.Options.RejectPrecisionLoss {
+		// The shortest representation of the float32 value must be the
+		// same number as the input.
+		v64, _ := strconv.ParseFloat(s, 64)
+		back, _ := strconv.ParseFloat(strconv.FormatFloat(v, 'g', -1, 32), 64)
+		if back != v64 && !math.IsNaN(v64) {
+			return errPrecisionLoss
+		}
+	}
+	*ff.Value = T(v)
+	return nil
+}
+
+func (ff *FormattedFloat[T]) bitSize() int {
+	return int(unsafe.Sizeof(*ff.Value)) * 8
+}
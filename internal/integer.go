@@ -0,0 +1,193 @@
+package internal
+
+import (
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// Integer is the constraint of the integer types supported by FormattedInt.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// IntOptions configures how an integer is converted from/to a string.
+type IntOptions struct {
+	// Prefixes accepts the Go syntax of integer literals while parsing, i.e.
+	// the base prefixes "0x", "0o", "0b" (and "0" for octal), and "_" digit
+	// separators, e.g. "0x10", "1_000_000".
+	Prefixes bool
+
+	// OutputBase is the base used while formatting, from 2 to 36. Defaults
+	// to 10. Bases 2, 8 and 16 are prefixed with "0b", "0o" and "0x", and the
+	// input with the prefix of OutputBase is accepted while parsing, e.g.
+	// "0xff". The unprefixed input is parsed in decimal, or in the Go syntax
+	// if Prefixes is set, except for the other bases, whose output has no
+	// prefix, e.g. "73" is parsed as 255 in base 36. So the output always
+	// round-trips.
+	OutputBase int
+
+	// Suffixes accepts the SI suffixes (k, M, G, T, P, E) and the IEC
+	// suffixes (Ki, Mi, Gi, Ti, Pi, Ei) while parsing, e.g. "10k", "4Ki",
+	// "1.5M". The value must be an integer after applying the suffix.
+	Suffixes bool
+}
+
+var (
+	siSuffixes  = "kMGTPE"
+	iecSuffixes = "KMGTPE"
+	reDecimal   = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)$`)
+	bigThousand = big.NewInt(1000)
+	bigKibi     = big.NewInt(1024)
+)
+
+// parse parses s as an integer of the given signedness and bit size.
+func (o *IntOptions) parse(s string, signed bool, bitSize int) (*big.Int, error) {
+	fn := "ParseUint"
+	if signed {
+		fn = "ParseInt"
+	}
+	syntaxError := &strconv.NumError{Func: fn, Num: s, Err: strconv.ErrSyntax}
+	rangeError := &strconv.NumError{Func: fn, Num: s, Err: strconv.ErrRange}
+
+	n, ok := o.setString(s)
+
+	// Try the suffixes only if s is not a plain integer, e.g. "0x1E" is a
+	// hex integer rather than 1 exa.
+	if !ok && o.Suffixes {
+		if mantissa, multiplier := splitIntSuffix(s); multiplier != nil {
+			if n, ok = o.setString(mantissa); ok {
+				n.Mul(n, multiplier)
+			} else if reDecimal.MatchString(mantissa) {
+				r, _ := new(big.Rat).SetString(mantissa)
+				r.Mul(r, new(big.Rat).SetInt(multiplier))
+				if !r.IsInt() {
+					return nil, syntaxError
+				}
+				n, ok = r.Num(), true
+			}
+		}
+	}
+	if !ok {
+		return nil, syntaxError
+	}
+
+	min, max := new(big.Int), new(big.Int).Lsh(big.NewInt(1), uint(bitSize))
+	if signed {
+		max.Rsh(max, 1)
+		min.Neg(max)
+	}
+	max.Sub(max, big.NewInt(1))
+	if n.Cmp(min) < 0 || n.Cmp(max) > 0 {
+		return nil, rangeError
+	}
+	return n, nil
+}
+
+// basePrefixes maps the second byte of the base prefixes to their bases.
+var basePrefixes = map[byte]int{'b': 2, 'B': 2, 'o': 8, 'O': 8, 'x': 16, 'X': 16}
+
+// setString parses s as an integer, see IntOptions.OutputBase.
+func (o *IntOptions) setString(s string) (*big.Int, bool) {
+	base := o.outputBase()
+	unsigned := strings.TrimLeft(s, "+-")
+	if len(s)-len(unsigned) > 1 {
+		return nil, false
+	}
+	if len(unsigned) > 2 && unsigned[0] == '0' {
+		if prefixBase, ok := basePrefixes[unsigned[1]]; ok {
+			if o.Prefixes {
+				return new(big.Int).SetString(s, 0)
+			}
+			if prefixBase == base && unsigned[2] != '+' && unsigned[2] != '-' {
+				return new(big.Int).SetString(s[:len(s)-len(unsigned)]+unsigned[2:], base)
+			}
+		}
+	}
+	if _, prefixed := outputPrefixes[base]; prefixed || base == 10 {
+		base = 10
+		if o.Prefixes {
+			base = 0
+		}
+	}
+	return new(big.Int).SetString(s, base)
+}
+
+// splitIntSuffix splits s into the mantissa and the multiplier of its
+// suffix. The multiplier is nil if s has no suffix. "K" is accepted as "k".
+func splitIntSuffix(s string) (string, *big.Int) {
+	if strings.HasSuffix(s, "i") && len(s) > 2 {
+		if i := strings.IndexByte(iecSuffixes, s[len(s)-2]); i >= 0 {
+			return s[:len(s)-2], new(big.Int).Exp(bigKibi, big.NewInt(int64(i+1)), nil)
+		}
+	}
+	if len(s) > 1 {
+		i := strings.IndexByte(siSuffixes, s[len(s)-1])
+		if s[len(s)-1] == 'K' {
+			i = 0
+		}
+		if i >= 0 {
+			return s[:len(s)-1], new(big.Int).Exp(bigThousand, big.NewInt(int64(i+1)), nil)
+		}
+	}
+	return s, nil
+}
+
+// outputPrefixes maps the output bases to their prefixes.
+var outputPrefixes = map[int]string{2: "0b", 8: "0o", 16: "0x"}
+
+func (o *IntOptions) format(digits string, neg bool) string {
+	prefix := outputPrefixes[o.OutputBase]
+	if neg {
+		return "-" + prefix + digits
+	}
+	return prefix + digits
+}
+
+func (o *IntOptions) outputBase() int {
+	if o.OutputBase < 2 || o.OutputBase > 36 {
+		return 10
+	}
+	return o.OutputBase
+}
+
+// FormattedInt is a wrapper of a pointer to an integer to implement
+// Stringable, which is converted with the given options.
+type FormattedInt[T Integer] struct {
+	Value   *T
+	Options *IntOptions
+}
+
+func (fi *FormattedInt[T]) ToString() (string, error) {
+	v := *fi.Value
+	if isSigned[T]() {
+		n := int64(v)
+		if n < 0 {
+			// Format the magnitude as uint64 to handle math.MinInt64.
+			return fi.Options.format(strconv.FormatUint(uint64(-n), fi.Options.outputBase()), true), nil
+		}
+		return fi.Options.format(strconv.FormatInt(n, fi.Options.outputBase()), false), nil
+	}
+	return fi.Options.format(strconv.FormatUint(uint64(v), fi.Options.outputBase()), false), nil
+}
+
+func (fi *FormattedInt[T]) FromString(s string) error {
+	signed := isSigned[T]()
+	n, err := fi.Options.parse(s, signed, int(unsafe.Sizeof(*fi.Value))*8)
+	if err != nil {
+		return err
+	}
+	if signed {
+		*fi.Value = T(n.Int64())
+	} else {
+		*fi.Value = T(n.Uint64())
+	}
+	return nil
+}
+
+func isSigned[T Integer]() bool {
+	var zero T
+	return zero-1 < zero
+}
@@ -0,0 +1,270 @@
+package internal
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// ZonePolicy is how the IPv6 zones, e.g. "%eth0" in "fe80::1%eth0", are
+// handled while parsing.
+type ZonePolicy int
+
+const (
+	ZoneKeep   ZonePolicy = iota // keeps the zone, or rejects it if the type can't hold one
+	ZoneStrip                    // strips the zone
+	ZoneReject                   // rejects the input with a zone
+)
+
+// NetOptions configures how the network types are converted from/to a
+// string.
+type NetOptions struct {
+	// UnmapIPv4 normalizes the IPv4-mapped IPv6 addresses, e.g.
+	// "::ffff:10.0.0.1", to IPv4 addresses while parsing.
+	UnmapIPv4 bool
+
+	// Zone is how the IPv6 zones are handled while parsing. Defaults to
+	// ZoneKeep.
+	Zone ZonePolicy
+}
+
+var (
+	defaultNetOptions = &NetOptions{}
+	errZoneNotAllowed = errors.New("IPv6 zone is not allowed")
+)
+
+func netOptions(o *NetOptions) *NetOptions {
+	if o == nil {
+		return defaultNetOptions
+	}
+	return o
+}
+
+func (o *NetOptions) normalizeAddr(addr netip.Addr) (netip.Addr, error) {
+	if o.UnmapIPv4 {
+		addr = addr.Unmap()
+	}
+	if addr.Zone() != "" {
+		switch o.Zone {
+		case ZoneStrip:
+			addr = addr.WithZone("")
+		case ZoneReject:
+			return netip.Addr{}, errZoneNotAllowed
+		}
+	}
+	return addr, nil
+}
+
+// stripZone removes the zone from s for the types that can't hold one. The
+// prefix length after the zone, if any, is kept, e.g. "fe80::1%eth0/64"
+// becomes "fe80::1/64".
+func (o *NetOptions) stripZone(s string) (string, error) {
+	i := strings.IndexByte(s, '%')
+	if i < 0 {
+		return s, nil
+	}
+	if o.Zone != ZoneStrip {
+		return "", errZoneNotAllowed
+	}
+	if j := strings.IndexByte(s[i:], '/'); j >= 0 {
+		return s[:i] + s[i+j:], nil
+	}
+	return s[:i], nil
+}
+
+// Addr is a wrapper of *netip.Addr to implement Stringable. The zero Addr is
+// converted from/to an empty string.
+type Addr struct {
+	Value   *netip.Addr
+	Options *NetOptions
+}
+
+func (a *Addr) ToString() (string, error) {
+	if !a.Value.IsValid() {
+		return "", nil
+	}
+	return a.Value.String(), nil
+}
+
+func (a *Addr) FromString(s string) error {
+	if s == "" {
+		*a.Value = netip.Addr{}
+		return nil
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return err
+	}
+	if addr, err = netOptions(a.Options).normalizeAddr(addr); err != nil {
+		return err
+	}
+	*a.Value = addr
+	return nil
+}
+
+// AddrPort is a wrapper of *netip.AddrPort to implement Stringable. The zero
+// AddrPort is converted from/to an empty string.
+type AddrPort struct {
+	Value   *netip.AddrPort
+	Options *NetOptions
+}
+
+func (ap *AddrPort) ToString() (string, error) {
+	if !ap.Value.IsValid() {
+		return "", nil
+	}
+	return ap.Value.String(), nil
+}
+
+func (ap *AddrPort) FromString(s string) error {
+	if s == "" {
+		*ap.Value = netip.AddrPort{}
+		return nil
+	}
+	v, err := netip.ParseAddrPort(s)
+	if err != nil {
+		return err
+	}
+	addr, err := netOptions(ap.Options).normalizeAddr(v.Addr())
+	if err != nil {
+		return err
+	}
+	*ap.Value = netip.AddrPortFrom(addr, v.Port())
+	return nil
+}
+
+// Prefix is a wrapper of *netip.Prefix to implement Stringable. The zero
+// Prefix is converted from/to an empty string.
+type Prefix struct {
+	Value   *netip.Prefix
+	Options *NetOptions
+}
+
+func (p *Prefix) ToString() (string, error) {
+	if !p.Value.IsValid() {
+		return "", nil
+	}
+	return p.Value.String(), nil
+}
+
+func (p *Prefix) FromString(s string) error {
+	if s == "" {
+		*p.Value = netip.Prefix{}
+		return nil
+	}
+	o := netOptions(p.Options)
+	s, err := o.stripZone(s)
+	if err != nil {
+		return err
+	}
+	v, err := netip.ParsePrefix(s)
+	if err != nil {
+		return err
+	}
+	if o.UnmapIPv4 && v.Addr().Is4In6() {
+		if v.Bits() < 96 {
+			return errors.New("IPv4-mapped prefix is shorter than 96 bits")
+		}
+		v = netip.PrefixFrom(v.Addr().Unmap(), v.Bits()-96)
+	}
+	*p.Value = v
+	return nil
+}
+
+// IP is a wrapper of *net.IP to implement Stringable. The nil IP is
+// converted from/to an empty string. As net.IP can't hold a zone, ZoneKeep
+// rejects the input with a zone.
+type IP struct {
+	Value   *net.IP
+	Options *NetOptions
+}
+
+func (ip *IP) ToString() (string, error) {
+	if len(*ip.Value) == 0 {
+		return "", nil
+	}
+	return ip.Value.String(), nil
+}
+
+func (ip *IP) FromString(s string) error {
+	if s == "" {
+		*ip.Value = nil
+		return nil
+	}
+	o := netOptions(ip.Options)
+	s, err := o.stripZone(s)
+	if err != nil {
+		return err
+	}
+	v := net.ParseIP(s)
+	if v == nil {
+		return &net.ParseError{Type: "IP address", Text: s}
+	}
+	if v4 := v.To4(); o.UnmapIPv4 && v4 != nil {
+		v = v4
+	}
+	*ip.Value = v
+	return nil
+}
+
+// IPNet is a wrapper of *net.IPNet to implement Stringable. The zero IPNet
+// is converted from/to an empty string. The host bits of the input are
+// masked, e.g. "10.1.2.3/8" is parsed as "10.0.0.0/8".
+type IPNet struct {
+	Value   *net.IPNet
+	Options *NetOptions
+}
+
+func (n *IPNet) ToString() (string, error) {
+	if len(n.Value.IP) == 0 {
+		return "", nil
+	}
+	return n.Value.String(), nil
+}
+
+func (n *IPNet) FromString(s string) error {
+	if s == "" {
+		*n.Value = net.IPNet{}
+		return nil
+	}
+	o := netOptions(n.Options)
+	s, err := o.stripZone(s)
+	if err != nil {
+		return err
+	}
+	_, v, err := net.ParseCIDR(s)
+	if err != nil {
+		return err
+	}
+	if ones, bits := v.Mask.Size(); o.UnmapIPv4 && bits == 128 && v.IP.To4() != nil {
+		if ones < 96 {
+			return errors.New("IPv4-mapped prefix is shorter than 96 bits")
+		}
+		v = &net.IPNet{IP: v.IP.To4(), Mask: net.CIDRMask(ones-96, 32)}
+	}
+	*n.Value = *v
+	return nil
+}
+
+// HardwareAddr is a wrapper of *net.HardwareAddr to implement Stringable,
+// e.g. "00:00:5e:00:53:01". The nil HardwareAddr is converted from/to an
+// empty string.
+type HardwareAddr net.HardwareAddr
+
+func (ha HardwareAddr) ToString() (string, error) {
+	return net.HardwareAddr(ha).String(), nil
+}
+
+func (ha *HardwareAddr) FromString(s string) error {
+	if s == "" {
+		*ha = nil
+		return nil
+	}
+	v, err := net.ParseMAC(s)
+	if err != nil {
+		return err
+	}
+	*ha = HardwareAddr(v)
+	return nil
+}
@@ -0,0 +1,140 @@
+package internal
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errInvalidRelativeTime = errors.New("invalid relative time expression")
+
+// relativeTimeKeywords are the symbolic times and their equivalent
+// expressions.
+var relativeTimeKeywords = []struct{ keyword, expr string }{
+	{"now", ""},
+	{"today", "/d"},
+	{"yesterday", "-1d/d"},
+	{"tomorrow", "+1d/d"},
+}
+
+// decodeRelativeTime parses a relative time expression, e.g. "now-15m",
+// "now+2d/d", "yesterday". The second return value reports whether value is
+// a relative time expression at all, i.e. a keyword followed by nothing or
+// an operation. Otherwise, the value is left to the layouts.
+//
+// After the keyword, there can be any number of operations:
+//   - "+<n><unit>" and "-<n><unit>" add or subtract n units.
+//   - "/<unit>" rounds the time down to the start of the unit.
+//
+// Where the units are "s", "m", "h", "d", "w", "M" (month) and "y". Weeks
+// start on Monday. The calendar units are computed in the DefaultLocation.
+func (o *TimeOptions) decodeRelativeTime(value string) (time.Time, bool, error) {
+	var expr string
+	matched := false
+	for _, kw := range relativeTimeKeywords {
+		if len(value) < len(kw.keyword) || !strings.EqualFold(value[:len(kw.keyword)], kw.keyword) {
+			continue
+		}
+		// The keyword must be followed by an operation or nothing, e.g.
+		// "nowhere" is not a relative time expression.
+		if rest := value[len(kw.keyword):]; rest == "" || strings.ContainsRune("+-/", rune(rest[0])) {
+			expr, matched = kw.expr+rest, true
+			break
+		}
+	}
+	if !matched {
+		return time.Time{}, false, nil
+	}
+
+	now := time.Now
+	if o.Now != nil {
+		now = o.Now
+	}
+	loc := o.DefaultLocation
+	if loc == nil {
+		loc = time.UTC
+	}
+	t := now().In(loc)
+
+	for expr != "" {
+		op := expr[0]
+		expr = expr[1:]
+		switch op {
+		case '+', '-':
+			i := strings.IndexFunc(expr, func(r rune) bool { return r < '0' || r > '9' })
+			if i <= 0 {
+				return time.Time{}, true, errInvalidRelativeTime
+			}
+			n, err := strconv.Atoi(expr[:i])
+			if err != nil {
+				return time.Time{}, true, errInvalidRelativeTime
+			}
+			if op == '-' {
+				n = -n
+			}
+			if t, err = addTimeUnits(t, n, expr[i]); err != nil {
+				return time.Time{}, true, err
+			}
+			expr = expr[i+1:]
+		case '/':
+			if expr == "" {
+				return time.Time{}, true, errInvalidRelativeTime
+			}
+			var err error
+			if t, err = roundTimeDown(t, expr[0]); err != nil {
+				return time.Time{}, true, err
+			}
+			expr = expr[1:]
+		default:
+			return time.Time{}, true, errInvalidRelativeTime
+		}
+	}
+	return o.normalize(t), true, nil
+}
+
+func addTimeUnits(t time.Time, n int, unit byte) (time.Time, error) {
+	switch unit {
+	case 's':
+		return t.Add(time.Duration(n) * time.Second), nil
+	case 'm':
+		return t.Add(time.Duration(n) * time.Minute), nil
+	case 'h':
+		return t.Add(time.Duration(n) * time.Hour), nil
+	case 'd':
+		return t.AddDate(0, 0, n), nil
+	case 'w':
+		return t.AddDate(0, 0, 7*n), nil
+	case 'M':
+		return t.AddDate(0, n, 0), nil
+	case 'y':
+		return t.AddDate(n, 0, 0), nil
+	default:
+		return time.Time{}, errInvalidRelativeTime
+	}
+}
+
+func roundTimeDown(t time.Time, unit byte) (time.Time, error) {
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	loc := t.Location()
+	switch unit {
+	case 's':
+		return time.Date(year, month, day, hour, min, sec, 0, loc), nil
+	case 'm':
+		return time.Date(year, month, day, hour, min, 0, 0, loc), nil
+	case 'h':
+		return time.Date(year, month, day, hour, 0, 0, 0, loc), nil
+	case 'd':
+		return time.Date(year, month, day, 0, 0, 0, 0, loc), nil
+	case 'w':
+		daysSinceMonday := (int(t.Weekday()) + 6) % 7
+		return time.Date(year, month, day-daysSinceMonday, 0, 0, 0, 0, loc), nil
+	case 'M':
+		return time.Date(year, month, 1, 0, 0, 0, 0, loc), nil
+	case 'y':
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, loc), nil
+	default:
+		return time.Time{}, errInvalidRelativeTime
+	}
+}
@@ -0,0 +1,200 @@
+package internal
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeOptions configures how a time.Time is converted from/to a string.
+type TimeOptions struct {
+	// Layouts are the layouts accepted while parsing, in priority order.
+	// Defaults to RFC3339Nano, "2006-01-02" and unix timestamps, see
+	// DecodeTime.
+	Layouts []string
+
+	// OutputLayout is the layout used while formatting. Defaults to
+	// RFC3339Nano.
+	OutputLayout string
+
+	// OutputLocation is the location used while formatting. Defaults to UTC,
+	// or the location of the value if PreserveOffset is true.
+	OutputLocation *time.Location
+
+	// PreserveOffset keeps the offset of the input while parsing, instead of
+	// converting the value to UTC. And the offset is echoed back while
+	// formatting if OutputLocation is not set.
+	PreserveOffset bool
+
+	// DefaultLocation is the location used while parsing the layouts
+	// without zone information, e.g. "2006-01-02 15:04:05". Defaults to UTC.
+	DefaultLocation *time.Location
+
+	// UnixPrecision is the precision of the unix timestamps while parsing.
+	// Defaults to UnixSeconds. Setting it also makes unix timestamps accepted
+	// when Layouts is set.
+	UnixPrecision UnixPrecision
+
+	// OutputUnix formats the value as an integer unix timestamp of the given
+	// precision, truncating the smaller units, instead of using OutputLayout.
+	// UnixAuto is treated as UnixSeconds.
+	OutputUnix UnixPrecision
+
+	// Relative accepts relative and symbolic time expressions while parsing,
+	// e.g. "now", "today", "yesterday", "now-15m", "now+2d/d". They're
+	// resolved against Now, and the calendar units, e.g. days, are computed
+	// in DefaultLocation.
+	Relative bool
+
+	// Now is the clock used to resolve the relative time expressions.
+	// Defaults to time.Now.
+	Now func() time.Time
+}
+
+// UnixPrecision is the precision of a unix timestamp.
+type UnixPrecision int
+
+const (
+	UnixSeconds UnixPrecision = iota + 1
+	UnixMilliseconds
+	UnixMicroseconds
+	UnixNanoseconds
+	// UnixAuto detects the precision by the magnitude of the timestamp, i.e.
+	// seconds below 1e11 (year 5138), milliseconds below 1e14, microseconds
+	// below 1e17, nanoseconds otherwise.
+	UnixAuto
+)
+
+var defaultTimeLayouts = []string{time.RFC3339Nano, "2006-01-02"}
+
+// Decode parses value as time.Time with the options.
+func (o *TimeOptions) Decode(value string) (time.Time, error) {
+	loc := o.DefaultLocation
+	if loc == nil {
+		loc = time.UTC
+	}
+	layouts, unix := o.Layouts, o.UnixPrecision != 0
+	if len(layouts) == 0 {
+		layouts, unix = defaultTimeLayouts, true
+	}
+
+	if o.Relative {
+		if t, ok, err := o.decodeRelativeTime(value); ok {
+			return t, err
+		}
+	}
+
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return o.normalize(t), nil
+		}
+	}
+	if unix && reUnixtime.MatchString(value) {
+		precision := o.UnixPrecision
+		if precision == 0 {
+			precision = UnixSeconds
+		}
+		return DecodeUnixtimeWithPrecision(value, precision)
+	}
+	return time.Time{}, errors.New("invalid time value")
+}
+
+func (o *TimeOptions) normalize(t time.Time) time.Time {
+	if o.PreserveOffset {
+		return t
+	}
+	return t.UTC()
+}
+
+// Encode formats t as a string with the options.
+func (o *TimeOptions) Encode(t time.Time) string {
+	switch o.OutputUnix {
+	case UnixSeconds, UnixAuto:
+		return strconv.FormatInt(t.Unix(), 10)
+	case UnixMilliseconds:
+		return strconv.FormatInt(t.UnixMilli(), 10)
+	case UnixMicroseconds:
+		return strconv.FormatInt(t.UnixMicro(), 10)
+	case UnixNanoseconds:
+		return strconv.FormatInt(t.UnixNano(), 10)
+	}
+
+	switch {
+	case o.OutputLocation != nil:
+		t = t.In(o.OutputLocation)
+	case !o.PreserveOffset:
+		t = t.UTC()
+	}
+	layout := o.OutputLayout
+	if layout == "" {
+		layout = time.RFC3339Nano
+	}
+	return t.Format(layout)
+}
+
+// DecodeUnixtimeWithPrecision parses value, which must match reUnixtime, as a
+// unix timestamp of the given precision. The fraction smaller than a
+// nanosecond is truncated.
+func DecodeUnixtimeWithPrecision(value string, precision UnixPrecision) (time.Time, error) {
+	digits, neg := trimSign(value)
+	intPart, fracPart, _ := strings.Cut(digits, ".")
+	n, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil {
+		return time.Time{}, errors.New("invalid unix timestamp")
+	}
+
+	if precision == UnixAuto {
+		switch {
+		case n < 1e11:
+			precision = UnixSeconds
+		case n < 1e14:
+			precision = UnixMilliseconds
+		case n < 1e17:
+			precision = UnixMicroseconds
+		default:
+			precision = UnixNanoseconds
+		}
+	}
+
+	var unit int64 // in nanoseconds
+	switch precision {
+	case UnixMilliseconds:
+		unit = 1e6
+	case UnixMicroseconds:
+		unit = 1e3
+	case UnixNanoseconds:
+		unit = 1
+	default:
+		unit = 1e9
+	}
+
+	perSecond := 1e9 / unit
+	frac, _ := strconv.ParseInt(nanoSecondPrecision(fracPart), 10, 64)
+	sec := n / perSecond
+	nsec := (n%perSecond)*unit + frac*unit/1e9
+	if neg {
+		sec, nsec = -sec, -nsec
+	}
+	return time.Unix(sec, nsec).UTC(), nil
+}
+
+// FormattedTime is a wrapper of *time.Time to implement Stringable, which
+// is converted with the given options.
+type FormattedTime struct {
+	Value   *time.Time
+	Options *TimeOptions
+}
+
+func (ft *FormattedTime) ToString() (string, error) {
+	return ft.Options.Encode(*ft.Value), nil
+}
+
+func (ft *FormattedTime) FromString(s string) error {
+	if t, err := ft.Options.Decode(s); err != nil {
+		return err
+	} else {
+		*ft.Value = t
+		return nil
+	}
+}
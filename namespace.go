@@ -2,7 +2,14 @@ package stringable
 
 import (
 	"fmt"
+	"math/big"
+	"net"
+	"net/netip"
+	"net/url"
 	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ggicci/stringable/internal"
@@ -13,38 +20,101 @@ import (
 var defaultNS = NewNamespace()
 
 // Namespace is the place to register type adaptors (of AnyStringableAdaptor).
+// It's safe to register adaptors and create Stringables concurrently. Call
+// Freeze after registering all the adaptors to make the namespace read-only,
+// so that creating Stringables no longer requires locking.
+//
+// A namespace can have a parent namespace, whose adaptors are inherited by
+// the namespace, see WithParent and Namespace.Child.
 type Namespace struct {
+	mu       sync.RWMutex
+	frozen   atomic.Bool
 	adaptors map[reflect.Type]AnyStringableAdaptor
+	parent   *Namespace
+	defaults []Option
+}
+
+// NamespaceOption configures a Namespace on creation.
+type NamespaceOption func(*Namespace)
+
+// WithParent sets the parent of the namespace being created. The adaptors
+// registered in the parent, including the ones registered later, are
+// inherited by the namespace unless overridden.
+func WithParent(parent *Namespace) NamespaceOption {
+	return func(ns *Namespace) {
+		ns.parent = parent
+	}
+}
+
+// WithDefaultOptions sets the options applied to every call of New on the
+// namespace being created, before the options passed to New. The default
+// options of the parent namespace, if any, are applied first.
+//
+// Example:
+//
+//	ns := stringable.NewNamespace(stringable.WithDefaultOptions(
+//		stringable.WithTime(stringable.TimeOptions{OutputLayout: time.RFC1123}),
+//	))
+func WithDefaultOptions(opts ...Option) NamespaceOption {
+	return func(ns *Namespace) {
+		ns.defaults = append(ns.defaults, opts...)
+	}
 }
 
 // NewNamespace creates a namespace where you can register adaptors to
 // override/adapt the converting behaviours of existing types.
-func NewNamespace() *Namespace {
-	return &Namespace{
+func NewNamespace(opts ...NamespaceOption) *Namespace {
+	ns := &Namespace{
 		adaptors: make(map[reflect.Type]AnyStringableAdaptor),
 	}
+	for _, opt := range opts {
+		opt(ns)
+	}
+	return ns
+}
+
+// Child creates a namespace whose parent is c. It's a shortcut of
+// NewNamespace(WithParent(c)).
+func (c *Namespace) Child() *Namespace {
+	return NewNamespace(WithParent(c))
+}
+
+// Parent returns the parent of the namespace, nil if it has no parent.
+func (c *Namespace) Parent() *Namespace {
+	return c.parent
 }
 
 // New creates a Stringable instance from the given value. If the given value itself
 // is already a Stringable, it will return directly. Otherwise, it will try to create
 // a Stringable instance by trying the following approaches:
 //  1. check if there's a custom adaptor for the type of the given value,
-//     if so, use it to adapt the given value to a Stringable.
+//     if so, use it to adapt the given value to a Stringable. The adaptors
+//     are looked up from the namespace to its parent, and so on.
 //  2. same as above, but check the builtin adaptors, which support the builtin types,
-//     e.g. int, string, float64, etc.
-//  3. try to create a "hybrid" instance, which makes use of the methods FromString,
+//     e.g. int, string, float64, etc. A named type whose underlying type is one
+//     of the basic builtin types or []byte, e.g. type Port uint16, is also
+//     converted by the builtin adaptor, unless it implements any of the methods listed in
+//     the 4th approach.
+//  3. if the given value is a pointer to a pointer, e.g. **int, create a Stringable
+//     that allocates the inner pointer when necessary in FromString, and
+//     converts a nil inner pointer to the value specified by NullValue.
+//  4. try to create a "hybrid" instance, which makes use of the methods FromString,
 //     ToString, MarshalText and UnmarshalText to fullfill the Stringable interface.
+//  5. try to create a "composite" instance for slices, arrays and maps, whose
+//     elements are converted by the Stringables created from the same
+//     namespace, e.g. "1,2,3" for []int and "a=1,b=2" for map[string]int.
+//     See Separator and KeyValueSeparator to change the separators.
 //
-// It has three options:
+// It has the following options:
 //
 //	New(v)
 //
-// 1. with only default options, it will try all the 3 ways as listed above to
+// 1. with only default options, it will try all the ways as listed above to
 // create a Stringable.
 //
 //	New(v, NoHybrid())
 //
-// 2. without hybrid, i.e. won't try the 3rd method, returns an
+// 2. without hybrid, i.e. won't try the 4th method, returns an
 // ErrUnsupportedType error.
 //
 //	New(v, CompleteHybrid())
@@ -54,19 +124,44 @@ func NewNamespace() *Namespace {
 // while a "partial"/"incomplete" hybrid, one of theses two methods can be
 // absent, and the absent one always returns an error, either
 // ErrNotStringMarshaler or ErrNotStringUnmarshaler.
+//
+//	New(v, NoKindFallback())
+//
+// 4. only the exact builtin types are converted by the builtin adaptors, i.e.
+// named types like Port above won't be converted by their underlying kinds.
 func (c *Namespace) New(v any, opts ...Option) (Stringable, error) {
 	if vs, ok := v.(Stringable); ok {
 		return vs, nil
 	}
 
+	return c.createStringable(v, c.options(opts))
+}
+
+// options returns the options of a call to New, with the default options of
+// the namespace and its ancestors applied.
+func (c *Namespace) options(opts []Option) *options {
+	var chain []*Namespace
+	for ns := c; ns != nil; ns = ns.parent {
+		chain = append(chain, ns)
+	}
+
 	options := defaultOptions()
+	for i := len(chain) - 1; i >= 0; i-- {
+		for _, opt := range chain[i].defaults {
+			opt(options)
+		}
+	}
 	for _, opt := range opts {
 		opt(options)
 	}
-	return c.createStringable(v, options)
+	return options
 }
 
 func (c *Namespace) createStringable(v any, opts *options) (Stringable, error) {
+	if opts.err != nil {
+		return nil, opts.err
+	}
+
 	rv, ok := v.(reflect.Value)
 	if !ok {
 		rv = reflect.ValueOf(v)
@@ -78,32 +173,81 @@ func (c *Namespace) createStringable(v any, opts *options) (Stringable, error) {
 		return nil, fmt.Errorf("%w: value must be a non-nil pointer", ErrNilPointer)
 	}
 
+	sb, path, err := c.resolveStringable(rv, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &conversion{Stringable: sb, typ: rv.Type().Elem(), path: path}, nil
+}
+
+// resolveStringable creates a Stringable from rv, a non-nil pointer, and
+// reports the approach used to create it.
+func (c *Namespace) resolveStringable(rv reflect.Value, opts *options) (Stringable, Path, error) {
 	baseType := rv.Type().Elem()
 
 	// Check if there is a custom adaptor for the base type.
-	if adapt, ok := c.adaptors[baseType]; ok {
-		return adapt(rv.Interface())
+	if adapt, ok := c.lookup(baseType); ok {
+		sb, err := adapt(rv.Interface())
+		return sb, PathAdaptor, err
 	}
 
 	// Check if there is a built-in adaptor for the base type.
 	if adapt, ok := builtinStringableAdaptors[baseType]; ok {
-		return adapt(rv.Interface())
+		sb, err := adapt(rv.Interface(), opts)
+		return sb, PathBuiltin, err
+	}
+
+	// Try to create a Stringable for a pointer to a pointer, e.g. **int.
+	if ps, err := c.createPointerStringable(rv, opts); ps != nil || err != nil {
+		return ps, PathPointer, err
+	}
+
+	// A named type that implements any of the hybrid interfaces, e.g.
+	// encoding.TextUnmarshaler, has its own idea of how to be converted, so we
+	// don't convert it by its underlying kind.
+	h := createHybridStringable(rv)
+
+	// Check if there is a built-in adaptor for the underlying kind of the
+	// base type, e.g. type Port uint16.
+	if h == nil && !opts.Has(optionNoKindFallback) {
+		builtinType, ok := kindFallbackTypes[baseType.Kind()]
+		if baseType.Kind() == reflect.Slice && baseType.Elem() == typeOf[uint8]() {
+			builtinType, ok = typeOf[[]byte](), true
+		}
+		if ok {
+			adapt := builtinStringableAdaptors[builtinType]
+			v := rv.Convert(reflect.PointerTo(builtinType)).Interface()
+			sb, err := adapt(v, opts)
+			if err == nil && builtinType == typeOf[int64]() {
+				sb = &durationFallback{Stringable: sb, v: v.(*int64)}
+			}
+			return sb, PathKind, err
+		}
+	}
+
+	// Check if the base type is a fixed-size byte array, e.g. [32]byte.
+	if h == nil && isByteArray(baseType) {
+		return createByteArrayStringable(rv, opts), PathBuiltin, nil
 	}
 
 	// Try to create a hybrid Stringable from the reflect.Value.
 	if !opts.Has(optionNoHybrid) {
-		h := createHybridStringable(rv)
 		if h != nil {
 			if opts.Has(optionCompleteHybrid) {
 				if err := h.(*hybrid).validateAsComplete(); err != nil {
-					return nil, err
+					return nil, PathHybrid, err
 				}
 			}
-			return h, nil
+			return h, PathHybrid, nil
 		}
 	}
 
-	return nil, unsupportedType(baseType)
+	// Try to create a composite Stringable, e.g. for slices, arrays and maps.
+	if cs, err := c.createCompositeStringable(rv, opts); cs != nil || err != nil {
+		return cs, PathComposite, err
+	}
+
+	return nil, 0, unsupportedType(baseType)
 }
 
 // Adapt registers a custom adaptor for the given type.
@@ -118,31 +262,256 @@ func (c *Namespace) createStringable(v any, opts *options) (Stringable, error) {
 //		// todo
 //	})
 //	ns.Adapt(typ, adaptor)
+//
+// It panics with ErrFrozenNamespace if the namespace has been frozen.
 func (c *Namespace) Adapt(typ reflect.Type, adaptor AnyStringableAdaptor) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mustNotBeFrozen()
 	c.adaptors[typ] = adaptor
 }
 
+// Freeze makes the namespace read-only. Registering or removing adaptors on
+// a frozen namespace panics with ErrFrozenNamespace. Freezing a frozen
+// namespace is a no-op.
+func (c *Namespace) Freeze() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.frozen.Store(true)
+}
+
+// IsFrozen reports whether the namespace has been frozen.
+func (c *Namespace) IsFrozen() bool {
+	return c.frozen.Load()
+}
+
+func (c *Namespace) mustNotBeFrozen() {
+	if c.IsFrozen() {
+		panic(ErrFrozenNamespace)
+	}
+}
+
+// lookup returns the custom adaptor registered for the given type, walking
+// from the namespace up to its ancestors.
+func (c *Namespace) lookup(typ reflect.Type) (AnyStringableAdaptor, bool) {
+	for ns := c; ns != nil; ns = ns.parent {
+		if adapt, ok := ns.ownAdaptor(typ); ok {
+			return adapt, true
+		}
+	}
+	return nil, false
+}
+
+func (c *Namespace) ownAdaptor(typ reflect.Type) (AnyStringableAdaptor, bool) {
+	// The adaptors won't change once the namespace is frozen.
+	if !c.IsFrozen() {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+	adapt, ok := c.adaptors[typ]
+	return adapt, ok
+}
+
+// AdaptedTypes returns the types that have custom adaptors registered in the
+// namespace, including the ones inherited from its ancestors, sorted by their
+// names.
+func (c *Namespace) AdaptedTypes() []reflect.Type {
+	seen := make(map[reflect.Type]bool)
+	var types []reflect.Type
+	for ns := c; ns != nil; ns = ns.parent {
+		ns.mu.RLock()
+		for typ := range ns.adaptors {
+			if !seen[typ] {
+				seen[typ] = true
+				types = append(types, typ)
+			}
+		}
+		ns.mu.RUnlock()
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].String() < types[j].String() })
+	return types
+}
+
+// Adapt registers a custom adaptor for type T in the namespace. It's the
+// type-safe version of Namespace.Adapt.
+//
+// Example:
+//
+//	ns := stringable.NewNamespace()
+//	stringable.Adapt(ns, func(b *bool) (stringable.Stringable, error) {
+//		return (*YesNo)(b), nil
+//	})
+func Adapt[T any](ns *Namespace, adaptor StringableAdaptor[T]) {
+	ns.Adapt(ToAnyStringableAdaptor[T](adaptor))
+}
+
+// Unadapt removes the custom adaptor registered for type T from the
+// namespace, if any. The adaptor inherited from the ancestors of the
+// namespace, if any, takes effect then. It panics with ErrFrozenNamespace if the namespace has
+// been frozen.
+func Unadapt[T any](ns *Namespace) {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	ns.mustNotBeFrozen()
+	delete(ns.adaptors, typeOf[T]())
+}
+
+// Lookup returns the custom adaptor registered for type T in the namespace or
+// its ancestors. The second return value reports whether such an adaptor
+// exists.
+func Lookup[T any](ns *Namespace) (StringableAdaptor[T], bool) {
+	adapt, ok := ns.lookup(typeOf[T]())
+	if !ok {
+		return nil, false
+	}
+	return func(v *T) (Stringable, error) { return adapt(v) }, true
+}
+
+// kindFallbackTypes maps the kinds to the builtin types, whose builtin
+// adaptors can be used to convert the named types of the same kinds.
+var kindFallbackTypes = map[reflect.Kind]reflect.Type{
+	reflect.String:     typeOf[string](),
+	reflect.Bool:       typeOf[bool](),
+	reflect.Int:        typeOf[int](),
+	reflect.Int8:       typeOf[int8](),
+	reflect.Int16:      typeOf[int16](),
+	reflect.Int32:      typeOf[int32](),
+	reflect.Int64:      typeOf[int64](),
+	reflect.Uint:       typeOf[uint](),
+	reflect.Uint8:      typeOf[uint8](),
+	reflect.Uint16:     typeOf[uint16](),
+	reflect.Uint32:     typeOf[uint32](),
+	reflect.Uint64:     typeOf[uint64](),
+	reflect.Float32:    typeOf[float32](),
+	reflect.Float64:    typeOf[float64](),
+	reflect.Complex64:  typeOf[complex64](),
+	reflect.Complex128: typeOf[complex128](),
+}
+
+// durationFallback converts a named type whose underlying kind is int64,
+// which can't be told apart from a named type of time.Duration by
+// reflection, e.g. type Timeout time.Duration. So an input that is not an
+// integer is parsed as a duration, e.g. "5s".
+type durationFallback struct {
+	Stringable
+	v *int64
+}
+
+func (d *durationFallback) FromString(s string) error {
+	err := d.Stringable.FromString(s)
+	if err == nil {
+		return nil
+	}
+	if dur, derr := internal.DecodeDuration(s); derr == nil {
+		*d.v = int64(dur)
+		return nil
+	}
+	return err
+}
+
+// conversion wraps a Stringable created by Namespace.New, to report the
+// errors occurred in the conversions as *ConversionError.
+type conversion struct {
+	Stringable
+	typ  reflect.Type
+	path Path
+}
+
+func (c *conversion) ToString() (string, error) {
+	s, err := c.Stringable.ToString()
+	if err != nil {
+		return "", c.wrap(err, DirectionToString, "")
+	}
+	return s, nil
+}
+
+func (c *conversion) FromString(s string) error {
+	if err := c.Stringable.FromString(s); err != nil {
+		return c.wrap(err, DirectionFromString, s)
+	}
+	return nil
+}
+
+func (c *conversion) wrap(err error, direction Direction, input string) error {
+	// Avoid wrapping the error reported by a nested conversion of the same
+	// value, e.g. the value being pointed to by a pointer.
+	if _, ok := err.(*ConversionError); ok {
+		return err
+	}
+	return &ConversionError{
+		Type:      c.typ,
+		Input:     truncate(input, maxErrorInputLength),
+		Direction: direction,
+		Path:      c.path,
+		Err:       err,
+	}
+}
+
 func unsupportedType(rt reflect.Type) error {
 	return fmt.Errorf("%w: %v", ErrUnsupportedType, rt)
 }
 
 func init() {
 	builtinStringable[string](func(v *string) (Stringable, error) { return (*internal.String)(v), nil })
-	builtinStringable[bool](func(v *bool) (Stringable, error) { return (*internal.Bool)(v), nil })
-	builtinStringable[int](func(v *int) (Stringable, error) { return (*internal.Int)(v), nil })
-	builtinStringable[int8](func(v *int8) (Stringable, error) { return (*internal.Int8)(v), nil })
-	builtinStringable[int16](func(v *int16) (Stringable, error) { return (*internal.Int16)(v), nil })
-	builtinStringable[int32](func(v *int32) (Stringable, error) { return (*internal.Int32)(v), nil })
-	builtinStringable[int64](func(v *int64) (Stringable, error) { return (*internal.Int64)(v), nil })
-	builtinStringable[uint](func(v *uint) (Stringable, error) { return (*internal.Uint)(v), nil })
-	builtinStringable[uint8](func(v *uint8) (Stringable, error) { return (*internal.Uint8)(v), nil })
-	builtinStringable[uint16](func(v *uint16) (Stringable, error) { return (*internal.Uint16)(v), nil })
-	builtinStringable[uint32](func(v *uint32) (Stringable, error) { return (*internal.Uint32)(v), nil })
-	builtinStringable[uint64](func(v *uint64) (Stringable, error) { return (*internal.Uint64)(v), nil })
-	builtinStringable[float32](func(v *float32) (Stringable, error) { return (*internal.Float32)(v), nil })
-	builtinStringable[float64](func(v *float64) (Stringable, error) { return (*internal.Float64)(v), nil })
+	builtinConfigurableStringable[bool](func(v *bool, opts *options) (Stringable, error) {
+		if opts.Bool != nil {
+			return &internal.FormattedBool{Value: v, Options: opts.Bool}, nil
+		}
+		return (*internal.Bool)(v), nil
+	})
+	builtinConfigurableStringable(integerAdaptor(func(v *int) Stringable { return (*internal.Int)(v) }))
+	builtinConfigurableStringable(integerAdaptor(func(v *int8) Stringable { return (*internal.Int8)(v) }))
+	builtinConfigurableStringable(integerAdaptor(func(v *int16) Stringable { return (*internal.Int16)(v) }))
+	builtinConfigurableStringable(integerAdaptor(func(v *int32) Stringable { return (*internal.Int32)(v) }))
+	builtinConfigurableStringable(integerAdaptor(func(v *int64) Stringable { return (*internal.Int64)(v) }))
+	builtinConfigurableStringable(integerAdaptor(func(v *uint) Stringable { return (*internal.Uint)(v) }))
+	builtinConfigurableStringable(integerAdaptor(func(v *uint8) Stringable { return (*internal.Uint8)(v) }))
+	builtinConfigurableStringable(integerAdaptor(func(v *uint16) Stringable { return (*internal.Uint16)(v) }))
+	builtinConfigurableStringable(integerAdaptor(func(v *uint32) Stringable { return (*internal.Uint32)(v) }))
+	builtinConfigurableStringable(integerAdaptor(func(v *uint64) Stringable { return (*internal.Uint64)(v) }))
+	builtinConfigurableStringable(floatAdaptor(func(v *float32) Stringable { return (*internal.Float32)(v) }))
+	builtinConfigurableStringable(floatAdaptor(func(v *float64) Stringable { return (*internal.Float64)(v) }))
 	builtinStringable[complex64](func(v *complex64) (Stringable, error) { return (*internal.Complex64)(v), nil })
 	builtinStringable[complex128](func(v *complex128) (Stringable, error) { return (*internal.Complex128)(v), nil })
-	builtinStringable[time.Time](func(v *time.Time) (Stringable, error) { return (*internal.Time)(v), nil })
-	builtinStringable[[]byte](func(b *[]byte) (Stringable, error) { return (*internal.ByteSlice)(b), nil })
+	builtinConfigurableStringable[time.Time](func(v *time.Time, opts *options) (Stringable, error) {
+		if opts.Time != nil {
+			return &internal.FormattedTime{Value: v, Options: opts.Time}, nil
+		}
+		return (*internal.Time)(v), nil
+	})
+	builtinStringable[time.Duration](func(v *time.Duration) (Stringable, error) { return (*internal.Duration)(v), nil })
+	builtinConfigurableStringable[big.Int](func(v *big.Int, opts *options) (Stringable, error) {
+		return &internal.BigInt{Value: v, Options: opts.Big}, nil
+	})
+	builtinConfigurableStringable[big.Float](func(v *big.Float, opts *options) (Stringable, error) {
+		return &internal.BigFloat{Value: v, Options: opts.Big}, nil
+	})
+	builtinConfigurableStringable[big.Rat](func(v *big.Rat, opts *options) (Stringable, error) {
+		return &internal.BigRat{Value: v, Options: opts.Big}, nil
+	})
+	builtinConfigurableStringable[netip.Addr](func(v *netip.Addr, opts *options) (Stringable, error) {
+		return &internal.Addr{Value: v, Options: opts.Net}, nil
+	})
+	builtinConfigurableStringable[netip.AddrPort](func(v *netip.AddrPort, opts *options) (Stringable, error) {
+		return &internal.AddrPort{Value: v, Options: opts.Net}, nil
+	})
+	builtinConfigurableStringable[netip.Prefix](func(v *netip.Prefix, opts *options) (Stringable, error) {
+		return &internal.Prefix{Value: v, Options: opts.Net}, nil
+	})
+	builtinConfigurableStringable[net.IP](func(v *net.IP, opts *options) (Stringable, error) {
+		return &internal.IP{Value: v, Options: opts.Net}, nil
+	})
+	builtinConfigurableStringable[net.IPNet](func(v *net.IPNet, opts *options) (Stringable, error) {
+		return &internal.IPNet{Value: v, Options: opts.Net}, nil
+	})
+	builtinStringable[net.HardwareAddr](func(v *net.HardwareAddr) (Stringable, error) { return (*internal.HardwareAddr)(v), nil })
+	builtinConfigurableStringable[url.URL](func(v *url.URL, opts *options) (Stringable, error) {
+		return &internal.URL{Value: v, Options: opts.URL}, nil
+	})
+	builtinConfigurableStringable[[]byte](func(b *[]byte, opts *options) (Stringable, error) {
+		if opts.BytesEncoding != 0 {
+			return &internal.EncodedBytes{Value: b, Encoding: opts.BytesEncoding}, nil
+		}
+		return (*internal.ByteSlice)(b), nil
+	})
 }
@@ -1,8 +1,14 @@
 package stringable
 
 import (
+	"errors"
+	"reflect"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/ggicci/stringable/internal"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -76,3 +82,268 @@ func TestNamespace_NewWithCompleteHybridOption(t *testing.T) {
 	assert.NotNil(t, sb)
 	assert.NoError(t, err)
 }
+
+type Port uint16
+type Env string
+
+// Level is a named int type that has its own text representation.
+type Level int
+
+func (l *Level) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "debug":
+		*l = 0
+	case "info":
+		*l = 1
+	default:
+		return errors.New("unknown level")
+	}
+	return nil
+}
+
+func TestNamespace_NewWithNamedTypes(t *testing.T) {
+	ns := NewNamespace()
+
+	var port Port = 8080
+	sb, err := ns.New(&port)
+	assert.NoError(t, err)
+	got, err := sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "8080", got)
+	assert.NoError(t, sb.FromString("443"))
+	assert.Equal(t, Port(443), port)
+	assert.Error(t, sb.FromString("65536"))
+
+	var env Env
+	sb, err = ns.New(&env)
+	assert.NoError(t, err)
+	assert.NoError(t, sb.FromString("production"))
+	assert.Equal(t, Env("production"), env)
+
+	var ports []Port
+	sb, err = ns.New(&ports)
+	assert.NoError(t, err)
+	assert.NoError(t, sb.FromString("80,443"))
+	assert.Equal(t, []Port{80, 443}, ports)
+}
+
+// Timeout is a named type of time.Duration, whose underlying kind is int64.
+type Timeout time.Duration
+
+func TestNamespace_NewWithNamedTypes_Duration(t *testing.T) {
+	got, err := Parse[Timeout]("5s")
+	assert.NoError(t, err)
+	assert.Equal(t, Timeout(5*time.Second), got)
+
+	got, err = Parse[Timeout]("1000")
+	assert.NoError(t, err)
+	assert.Equal(t, Timeout(1000), got, "should parse an integer as is")
+
+	_, err = Parse[Timeout]("five")
+	assert.ErrorIs(t, err, strconv.ErrSyntax, "should report the error of the integer")
+
+	s, err := Format(Timeout(5 * time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, "5000000000", s)
+}
+
+func TestNamespace_NewWithNamedTypes_HybridTakesPrecedence(t *testing.T) {
+	ns := NewNamespace()
+
+	var level Level
+	sb, err := ns.New(&level)
+	assert.NoError(t, err)
+	assert.NoError(t, sb.FromString("info"))
+	assert.Equal(t, Level(1), level)
+	assert.Error(t, sb.FromString("1"))
+
+	sb, err = ns.New(&level, NoHybrid())
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+	assert.Nil(t, sb)
+}
+
+func TestNamespace_NewWithNoKindFallbackOption(t *testing.T) {
+	ns := NewNamespace()
+
+	var port Port
+	sb, err := ns.New(&port, NoKindFallback())
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+	assert.Nil(t, sb)
+
+	var i int
+	sb, err = ns.New(&i, NoKindFallback())
+	assert.NoError(t, err)
+	assert.NotNil(t, sb)
+}
+
+func TestNamespace_NewWithNamedTypes_AdaptorTakesPrecedence(t *testing.T) {
+	ns := NewNamespace()
+	typ, adaptor := ToAnyStringableAdaptor(func(p *Port) (Stringable, error) {
+		return (*internal.String)(pointerize(string("port"))), nil
+	})
+	ns.Adapt(typ, adaptor)
+
+	var port Port
+	sb, err := ns.New(&port)
+	assert.NoError(t, err)
+	got, err := sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "port", got)
+}
+
+func TestAdapt(t *testing.T) {
+	ns := NewNamespace()
+	Adapt(ns, func(b *bool) (Stringable, error) {
+		return (*YesNo)(b), nil
+	})
+
+	var yesno bool = true
+	sb, err := ns.New(&yesno)
+	assert.NoError(t, err)
+	got, err := sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "yes", got)
+}
+
+func TestLookup(t *testing.T) {
+	ns := NewNamespace()
+	adapt, ok := Lookup[bool](ns)
+	assert.False(t, ok)
+	assert.Nil(t, adapt)
+
+	Adapt(ns, func(b *bool) (Stringable, error) {
+		return (*YesNo)(b), nil
+	})
+	adapt, ok = Lookup[bool](ns)
+	assert.True(t, ok)
+
+	var yesno bool
+	sb, err := adapt(&yesno)
+	assert.NoError(t, err)
+	assert.NoError(t, sb.FromString("yes"))
+	assert.True(t, yesno)
+}
+
+func TestUnadapt(t *testing.T) {
+	ns := NewNamespace()
+	Adapt(ns, func(b *bool) (Stringable, error) {
+		return (*YesNo)(b), nil
+	})
+	Unadapt[bool](ns)
+	Unadapt[int](ns) // no-op
+
+	_, ok := Lookup[bool](ns)
+	assert.False(t, ok)
+
+	var b bool
+	sb, err := ns.New(&b)
+	assert.NoError(t, err)
+	assert.NoError(t, sb.FromString("true"))
+	assert.True(t, b)
+}
+
+func TestNamespace_AdaptedTypes(t *testing.T) {
+	ns := NewNamespace()
+	assert.Empty(t, ns.AdaptedTypes())
+
+	Adapt(ns, func(v *string) (Stringable, error) { return (*internal.String)(v), nil })
+	Adapt(ns, func(b *bool) (Stringable, error) { return (*YesNo)(b), nil })
+	assert.Equal(t, []reflect.Type{typeOf[bool](), typeOf[string]()}, ns.AdaptedTypes())
+}
+
+func TestNamespace_Freeze(t *testing.T) {
+	ns := NewNamespace()
+	Adapt(ns, func(b *bool) (Stringable, error) { return (*YesNo)(b), nil })
+	assert.False(t, ns.IsFrozen())
+
+	ns.Freeze()
+	ns.Freeze() // no-op
+	assert.True(t, ns.IsFrozen())
+
+	assert.PanicsWithValue(t, ErrFrozenNamespace, func() {
+		Adapt(ns, func(v *string) (Stringable, error) { return (*internal.String)(v), nil })
+	})
+	assert.PanicsWithValue(t, ErrFrozenNamespace, func() {
+		Unadapt[bool](ns)
+	})
+
+	var yesno bool
+	sb, err := ns.New(&yesno)
+	assert.NoError(t, err)
+	assert.NoError(t, sb.FromString("yes"))
+	assert.True(t, yesno)
+}
+
+func TestNamespace_ConcurrentAdaptAndNew(t *testing.T) {
+	ns := NewNamespace()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			Adapt(ns, func(b *bool) (Stringable, error) { return (*YesNo)(b), nil })
+			Unadapt[bool](ns)
+			ns.AdaptedTypes()
+		}()
+		go func() {
+			defer wg.Done()
+			var b bool
+			_, err := ns.New(&b)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestNamespace_Child(t *testing.T) {
+	org := NewNamespace()
+	Adapt(org, func(b *bool) (Stringable, error) { return (*YesNo)(b), nil })
+
+	service := org.Child()
+	assert.Same(t, org, service.Parent())
+	assert.Nil(t, org.Parent())
+
+	// Inherited from the parent.
+	var yesno bool
+	sb, err := service.New(&yesno)
+	assert.NoError(t, err)
+	assert.NoError(t, sb.FromString("yes"))
+	assert.True(t, yesno)
+
+	// Overridden by the child.
+	Adapt(service, func(b *bool) (Stringable, error) { return (*internal.Bool)(b), nil })
+	sb, err = service.New(&yesno)
+	assert.NoError(t, err)
+	assert.NoError(t, sb.FromString("false"))
+	assert.False(t, yesno)
+
+	// The parent is not affected.
+	sb, err = org.New(&yesno)
+	assert.NoError(t, err)
+	assert.Error(t, sb.FromString("false"))
+
+	// Falls back to the parent again after removing the override.
+	Unadapt[bool](service)
+	_, ok := Lookup[bool](service)
+	assert.True(t, ok)
+}
+
+func TestNamespace_WithParent(t *testing.T) {
+	root := NewNamespace()
+	parent := NewNamespace(WithParent(root))
+	child := NewNamespace(WithParent(parent))
+
+	// Adaptors registered in the ancestors later are also inherited.
+	Adapt(root, func(b *bool) (Stringable, error) { return (*YesNo)(b), nil })
+	Adapt(parent, func(v *string) (Stringable, error) { return (*internal.String)(v), nil })
+	Adapt(child, func(b *bool) (Stringable, error) { return (*internal.Bool)(b), nil })
+	assert.Equal(t, []reflect.Type{typeOf[bool](), typeOf[string]()}, child.AdaptedTypes())
+
+	var yesno bool
+	child.Freeze()
+	sb, err := child.New(&yesno)
+	assert.NoError(t, err)
+	assert.NoError(t, sb.FromString("true"))
+	assert.True(t, yesno)
+}
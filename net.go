@@ -0,0 +1,32 @@
+package stringable
+
+import "github.com/ggicci/stringable/internal"
+
+// NetOptions configures how the network types, i.e. netip.Addr,
+// netip.AddrPort, netip.Prefix, net.IP and net.IPNet, are converted from/to a
+// string, see WithNet.
+type NetOptions = internal.NetOptions
+
+// ZonePolicy is how the IPv6 zones, e.g. "%eth0" in "fe80::1%eth0", are
+// handled while parsing.
+type ZonePolicy = internal.ZonePolicy
+
+const (
+	ZoneKeep   = internal.ZoneKeep   // keeps the zone, or rejects it if the type can't hold one
+	ZoneStrip  = internal.ZoneStrip  // strips the zone
+	ZoneReject = internal.ZoneReject // rejects the input with a zone
+)
+
+// WithNet sets the options used to convert the network types.
+//
+// Example:
+//
+//	stringable.New(&allowlist, stringable.WithNet(stringable.NetOptions{
+//		UnmapIPv4: true,
+//		Zone:      stringable.ZoneReject,
+//	}))
+func WithNet(o NetOptions) Option {
+	return func(opts *options) {
+		opts.Net = &o
+	}
+}
@@ -0,0 +1,158 @@
+package stringable
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_NetipAddr(t *testing.T) {
+	var addr netip.Addr
+	sb, err := New(&addr)
+	assert.NoError(t, err)
+
+	s, err := sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "", s)
+
+	assert.NoError(t, sb.FromString("fe80::1%eth0"))
+	assert.Equal(t, "eth0", addr.Zone())
+	assert.NoError(t, sb.FromString("::ffff:10.0.0.1"))
+	assert.True(t, addr.Is4In6())
+	s, err = sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "::ffff:10.0.0.1", s)
+
+	assert.Error(t, sb.FromString("10.0.0.256"))
+	assert.Equal(t, netip.MustParseAddr("::ffff:10.0.0.1"), addr, "value should not change on error")
+
+	assert.NoError(t, sb.FromString(""))
+	assert.False(t, addr.IsValid())
+
+	addr, err = Parse[netip.Addr]("::ffff:10.0.0.1", WithNet(NetOptions{UnmapIPv4: true}))
+	assert.NoError(t, err)
+	assert.Equal(t, netip.MustParseAddr("10.0.0.1"), addr)
+
+	addr, err = Parse[netip.Addr]("fe80::1%eth0", WithNet(NetOptions{Zone: ZoneStrip}))
+	assert.NoError(t, err)
+	assert.Equal(t, netip.MustParseAddr("fe80::1"), addr)
+
+	_, err = Parse[netip.Addr]("fe80::1%eth0", WithNet(NetOptions{Zone: ZoneReject}))
+	assert.ErrorContains(t, err, "zone is not allowed")
+}
+
+func TestNew_NetipAddrPort(t *testing.T) {
+	ap, err := Parse[netip.AddrPort]("[::ffff:10.0.0.1]:8080", WithNet(NetOptions{UnmapIPv4: true}))
+	assert.NoError(t, err)
+	s, err := Format(ap)
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.1:8080", s)
+
+	_, err = Parse[netip.AddrPort]("[fe80::1%eth0]:80", WithNet(NetOptions{Zone: ZoneReject}))
+	assert.Error(t, err)
+	_, err = Parse[netip.AddrPort]("10.0.0.1")
+	assert.Error(t, err)
+
+	s, err = Format(netip.AddrPort{})
+	assert.NoError(t, err)
+	assert.Equal(t, "", s)
+}
+
+func TestNew_NetipPrefix(t *testing.T) {
+	p, err := Parse[netip.Prefix]("10.0.0.0/8")
+	assert.NoError(t, err)
+	assert.Equal(t, netip.MustParsePrefix("10.0.0.0/8"), p)
+
+	p, err = Parse[netip.Prefix]("::ffff:10.0.0.0/104", WithNet(NetOptions{UnmapIPv4: true}))
+	assert.NoError(t, err)
+	assert.Equal(t, netip.MustParsePrefix("10.0.0.0/8"), p)
+
+	_, err = Parse[netip.Prefix]("::ffff:0.0.0.0/64", WithNet(NetOptions{UnmapIPv4: true}))
+	assert.Error(t, err)
+	_, err = Parse[netip.Prefix]("10.0.0.0/33")
+	assert.Error(t, err)
+
+	p, err = Parse[netip.Prefix]("fe80::%eth0/64", WithNet(NetOptions{Zone: ZoneStrip}))
+	assert.NoError(t, err)
+	assert.Equal(t, netip.MustParsePrefix("fe80::/64"), p)
+	_, err = Parse[netip.Prefix]("fe80::%eth0/64")
+	assert.ErrorContains(t, err, "zone is not allowed")
+	_, err = Parse[netip.Prefix]("fe80::%eth0/64", WithNet(NetOptions{Zone: ZoneReject}))
+	assert.ErrorContains(t, err, "zone is not allowed")
+}
+
+func TestNew_NetIP(t *testing.T) {
+	var ip net.IP
+	sb, err := New(&ip)
+	assert.NoError(t, err)
+
+	s, err := sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "", s)
+
+	assert.NoError(t, sb.FromString("2001:db8::1"))
+	assert.Equal(t, net.ParseIP("2001:db8::1"), ip)
+	assert.Error(t, sb.FromString("localhost"))
+	assert.Equal(t, net.ParseIP("2001:db8::1"), ip, "value should not change on error")
+	assert.ErrorContains(t, sb.FromString("fe80::1%eth0"), "zone is not allowed")
+
+	ip, err = Parse[net.IP]("::ffff:10.0.0.1", WithNet(NetOptions{UnmapIPv4: true}))
+	assert.NoError(t, err)
+	assert.Len(t, ip, net.IPv4len)
+
+	ip, err = Parse[net.IP]("fe80::1%eth0", WithNet(NetOptions{Zone: ZoneStrip}))
+	assert.NoError(t, err)
+	assert.Equal(t, net.ParseIP("fe80::1"), ip)
+}
+
+func TestNew_NetIPNet(t *testing.T) {
+	var ipnet net.IPNet
+	sb, err := New(&ipnet)
+	assert.NoError(t, err)
+
+	assert.NoError(t, sb.FromString("10.1.2.3/8"))
+	s, err := sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.0/8", s)
+	assert.Error(t, sb.FromString("10.0.0.0"))
+	assert.Equal(t, "10.0.0.0/8", ipnet.String(), "value should not change on error")
+
+	assert.NoError(t, sb.FromString(""))
+	s, err = sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "", s)
+
+	ipnet, err = Parse[net.IPNet]("::ffff:10.0.0.0/104", WithNet(NetOptions{UnmapIPv4: true}))
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.0/8", ipnet.String())
+
+	ipnet, err = Parse[net.IPNet]("fe80::1%eth0/64", WithNet(NetOptions{Zone: ZoneStrip}))
+	assert.NoError(t, err)
+	assert.Equal(t, "fe80::/64", ipnet.String())
+	_, err = Parse[net.IPNet]("fe80::1%eth0/64")
+	assert.ErrorContains(t, err, "zone is not allowed")
+	_, err = Parse[net.IPNet]("fe80::1%eth0/64", WithNet(NetOptions{Zone: ZoneReject}))
+	assert.ErrorContains(t, err, "zone is not allowed")
+}
+
+func TestNew_NetHardwareAddr(t *testing.T) {
+	var mac net.HardwareAddr
+	sb, err := New(&mac)
+	assert.NoError(t, err)
+
+	assert.NoError(t, sb.FromString("00-00-5E-00-53-01"))
+	s, err := sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "00:00:5e:00:53:01", s)
+
+	assert.Error(t, sb.FromString("00:00:5e"))
+	assert.Equal(t, "00:00:5e:00:53:01", mac.String(), "value should not change on error")
+}
+
+func TestNew_NetIPSlice(t *testing.T) {
+	ips, err := Parse[[]netip.Addr]("10.0.0.1,::1")
+	assert.NoError(t, err)
+	assert.Equal(t, []netip.Addr{netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("::1")}, ips)
+}
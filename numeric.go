@@ -0,0 +1,73 @@
+package stringable
+
+import (
+	"fmt"
+
+	"github.com/ggicci/stringable/internal"
+)
+
+// IntOptions configures how integers are converted from/to a string, see
+// WithInt.
+type IntOptions = internal.IntOptions
+
+// WithInt sets the options used to convert integer values, i.e. int, int8,
+// int16, int32, int64, uint, uint8, uint16, uint32 and uint64.
+//
+// Example:
+//
+//	stringable.New(&maxBodySize, stringable.WithInt(stringable.IntOptions{
+//		Prefixes: true, // 0x10, 0o755, 1_000_000
+//		Suffixes: true, // 10k, 4Ki, 1.5M
+//	}))
+func WithInt(o IntOptions) Option {
+	return func(opts *options) {
+		opts.Int = &o
+	}
+}
+
+// FloatOptions configures how floating-point numbers are converted from/to a
+// string, see WithFloat.
+type FloatOptions = internal.FloatOptions
+
+// WithFloat sets the options used to convert float32 and float64 values.
+// New fails with ErrInvalidOption if the options are invalid, e.g. an
+// invalid Format.
+//
+// Example:
+//
+//	stringable.New(&ratio, stringable.WithFloat(stringable.FloatOptions{
+//		Format:    ".3e", // 1.234e+300
+//		RejectNaN: true,
+//		RejectInf: true,
+//	}))
+func WithFloat(o FloatOptions) Option {
+	return func(opts *options) {
+		if err := internal.ValidateFloatOptions(o); err != nil {
+			opts.err = fmt.Errorf("%w: %v", ErrInvalidOption, err)
+			return
+		}
+		opts.Float = &o
+	}
+}
+
+// integerAdaptor creates the builtin adaptor of an integer type, which
+// returns the given plain Stringable unless IntOptions are set.
+func integerAdaptor[T internal.Integer](plain func(*T) Stringable) func(*T, *options) (Stringable, error) {
+	return func(v *T, opts *options) (Stringable, error) {
+		if opts.Int != nil {
+			return &internal.FormattedInt[T]{Value: v, Options: opts.Int}, nil
+		}
+		return plain(v), nil
+	}
+}
+
+// floatAdaptor creates the builtin adaptor of a floating-point type, which
+// returns the given plain Stringable unless FloatOptions are set.
+func floatAdaptor[T internal.Float](plain func(*T) Stringable) func(*T, *options) (Stringable, error) {
+	return func(v *T, opts *options) (Stringable, error) {
+		if opts.Float != nil {
+			return &internal.FormattedFloat[T]{Value: v, Options: opts.Float}, nil
+		}
+		return plain(v), nil
+	}
+}
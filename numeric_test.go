@@ -0,0 +1,264 @@
+package stringable
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithInt_Prefixes(t *testing.T) {
+	opts := WithInt(IntOptions{Prefixes: true})
+	for input, expected := range map[string]int{
+		"0x10":      16,
+		"0X1f":      31,
+		"0o755":     493,
+		"0755":      493,
+		"0b1010":    10,
+		"1_000_000": 1000000,
+		"-0x10":     -16,
+		"42":        42,
+	} {
+		v, err := Parse[int](input, opts)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, v, input)
+	}
+
+	_, err := Parse[int]("0x10")
+	assert.Error(t, err, "prefixes are not accepted by default")
+	_, err = Parse[int]("1__0", opts)
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+}
+
+func TestWithInt_Suffixes(t *testing.T) {
+	opts := WithInt(IntOptions{Suffixes: true, Prefixes: true})
+	for input, expected := range map[string]int64{
+		"10k":   10000,
+		"10K":   10000,
+		"4Ki":   4096,
+		"1.5M":  1500000,
+		"10Mi":  10 * 1024 * 1024,
+		"2G":    2e9,
+		"1Ti":   1 << 40,
+		"-3k":   -3000,
+		".5k":   500,
+		"0x1E":  30, // hex digits take precedence over the suffixes
+		"0x10k": 16000,
+		"1E":    1e18,
+	} {
+		v, err := Parse[int64](input, opts)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, v, input)
+	}
+
+	for _, input := range []string{"1.5", "1.0001k", "k", "10x", "10Ki2", "10E", "1.5Xi"} {
+		_, err := Parse[int64](input, opts)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestWithInt_Overflow(t *testing.T) {
+	opts := WithInt(IntOptions{Suffixes: true})
+
+	v8, err := Parse[int8]("127", opts)
+	assert.NoError(t, err)
+	assert.Equal(t, int8(127), v8)
+	_, err = Parse[int8]("1k", opts)
+	assert.ErrorIs(t, err, strconv.ErrRange)
+	_, err = Parse[int8]("-129", opts)
+	assert.ErrorIs(t, err, strconv.ErrRange)
+
+	u16, err := Parse[uint16]("64Ki", opts)
+	assert.ErrorIs(t, err, strconv.ErrRange)
+	assert.Zero(t, u16)
+	u16, err = Parse[uint16]("63Ki", opts)
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(63*1024), u16)
+	_, err = Parse[uint16]("-1", opts)
+	assert.ErrorIs(t, err, strconv.ErrRange)
+
+	u64, err := Parse[uint64]("16Ei", opts)
+	assert.ErrorIs(t, err, strconv.ErrRange)
+	assert.Zero(t, u64)
+	u64, err = Parse[uint64]("18446744073709551615", opts)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(math.MaxUint64), u64)
+
+	var numErr *strconv.NumError
+	_, err = Parse[uint]("x", opts)
+	assert.True(t, errors.As(err, &numErr))
+	assert.Equal(t, "ParseUint", numErr.Func)
+}
+
+func TestWithInt_OutputBase(t *testing.T) {
+	for base, expected := range map[int]string{
+		0:  "-255",
+		2:  "-0b11111111",
+		8:  "-0o377",
+		10: "-255",
+		16: "-0xff",
+		36: "-73",
+		99: "-255",
+	} {
+		s, err := Format(-255, WithInt(IntOptions{OutputBase: base}))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, s, base)
+	}
+
+	s, err := Format(int64(math.MinInt64), WithInt(IntOptions{OutputBase: 16}))
+	assert.NoError(t, err)
+	assert.Equal(t, "-0x8000000000000000", s)
+
+	s, err = Format(uint8(16), WithInt(IntOptions{OutputBase: 16}))
+	assert.NoError(t, err)
+	assert.Equal(t, "0x10", s)
+
+	// Round trip with the prefixes.
+	opts := WithInt(IntOptions{Prefixes: true, OutputBase: 16})
+	var workers uint32 = 0x10
+	s, err = Format(workers, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, workers, MustParse[uint32](s, opts))
+}
+
+func TestWithInt_OutputBaseRoundTrip(t *testing.T) {
+	for base := 2; base <= 36; base++ {
+		for _, prefixes := range []bool{false, true} {
+			opts := WithInt(IntOptions{OutputBase: base, Prefixes: prefixes})
+			for _, v := range []int64{0, 1, -1, 255, -255, math.MaxInt64, math.MinInt64} {
+				s, err := Format(v, opts)
+				assert.NoError(t, err)
+				got, err := Parse[int64](s, opts)
+				assert.NoError(t, err, "base %d: %q", base, s)
+				assert.Equal(t, v, got, "base %d: %q", base, s)
+			}
+			for _, v := range []uint8{0, 16, math.MaxUint8} {
+				s, err := Format(v, opts)
+				assert.NoError(t, err)
+				got, err := Parse[uint8](s, opts)
+				assert.NoError(t, err, "base %d: %q", base, s)
+				assert.Equal(t, v, got, "base %d: %q", base, s)
+			}
+		}
+	}
+}
+
+func TestWithInt_OutputBaseParse(t *testing.T) {
+	for _, c := range []struct {
+		input    string
+		opts     IntOptions
+		expected int
+	}{
+		{"0xff", IntOptions{OutputBase: 16}, 255},
+		{"-0XFF", IntOptions{OutputBase: 16}, -255},
+		{"16", IntOptions{OutputBase: 16}, 16}, // unprefixed input is decimal
+		{"16", IntOptions{OutputBase: 16, Prefixes: true}, 16},
+		{"0b1", IntOptions{OutputBase: 16, Prefixes: true}, 1},
+		{"0o17", IntOptions{OutputBase: 8}, 15},
+		{"17", IntOptions{OutputBase: 8}, 17},
+		{"017", IntOptions{OutputBase: 8, Prefixes: true}, 15},
+		{"1_000", IntOptions{OutputBase: 2, Prefixes: true}, 1000},
+		{"73", IntOptions{OutputBase: 36}, 255},
+		{"073", IntOptions{OutputBase: 36}, 255},
+		{"0x10", IntOptions{OutputBase: 10, Prefixes: true}, 16},
+	} {
+		v, err := Parse[int](c.input, WithInt(c.opts))
+		assert.NoError(t, err, c.input)
+		assert.Equal(t, c.expected, v, c.input)
+	}
+
+	for _, c := range []struct {
+		input string
+		opts  IntOptions
+	}{
+		{"0xff", IntOptions{OutputBase: 8}},
+		{"ff", IntOptions{OutputBase: 16}},
+		{"0b1", IntOptions{OutputBase: 16}},
+		{"0x", IntOptions{OutputBase: 16}},
+		{"0x-1", IntOptions{OutputBase: 16}},
+		{"--1", IntOptions{OutputBase: 16}},
+		{"z", IntOptions{OutputBase: 16}},
+	} {
+		_, err := Parse[int](c.input, WithInt(c.opts))
+		assert.ErrorIs(t, err, strconv.ErrSyntax, c.input)
+	}
+}
+
+func TestWithInt_NamedTypes(t *testing.T) {
+	port, err := Parse[Port]("0x1F90", WithInt(IntOptions{Prefixes: true}))
+	assert.NoError(t, err)
+	assert.Equal(t, Port(8080), port)
+}
+
+func TestWithFloat_Format(t *testing.T) {
+	for format, expected := range map[string]string{
+		"":    "123456.789",
+		"f":   "123456.789",
+		".2f": "123456.79",
+		".0f": "123457",
+		"e":   "1.23456789e+05",
+		".3e": "1.235e+05",
+		"E":   "1.23456789E+05",
+		"g":   "123456.789",
+		".3g": "1.23e+05",
+	} {
+		s, err := Format(123456.789, WithFloat(FloatOptions{Format: format}))
+		assert.NoError(t, err, format)
+		assert.Equal(t, expected, s, format)
+	}
+
+	s, err := Format(1e300, WithFloat(FloatOptions{Format: "e"}))
+	assert.NoError(t, err)
+	assert.Equal(t, "1e+300", s)
+
+	s, err = Format(float32(0.1), WithFloat(FloatOptions{Format: "g"}))
+	assert.NoError(t, err)
+	assert.Equal(t, "0.1", s)
+
+	for _, format := range []string{"x", "zz", "2f", ".f", ".-1f", ".af"} {
+		var f float64
+		_, err := New(&f, WithFloat(FloatOptions{Format: format}))
+		assert.ErrorIs(t, err, ErrInvalidOption, format)
+		assert.ErrorContains(t, err, "invalid float format", format)
+	}
+}
+
+func TestWithFloat_SpecialValues(t *testing.T) {
+	v, err := Parse[float64]("NaN", WithFloat(FloatOptions{}))
+	assert.NoError(t, err)
+	assert.True(t, math.IsNaN(v))
+
+	opts := WithFloat(FloatOptions{RejectNaN: true, RejectInf: true})
+	for _, input := range []string{"NaN", "nan", "Inf", "+Inf", "-Inf", "infinity"} {
+		_, err := Parse[float64](input, opts)
+		assert.Error(t, err, input)
+	}
+	_, err = Parse[float32]("1e39", opts)
+	assert.ErrorIs(t, err, strconv.ErrRange)
+
+	_, err = Format(math.NaN(), opts)
+	assert.ErrorContains(t, err, "NaN is not allowed")
+	_, err = Format(math.Inf(-1), opts)
+	assert.ErrorContains(t, err, "Inf is not allowed")
+
+	_, err = Parse[float64]("Inf", WithFloat(FloatOptions{RejectNaN: true}))
+	assert.NoError(t, err)
+}
+
+func TestWithFloat_RejectPrecisionLoss(t *testing.T) {
+	opts := WithFloat(FloatOptions{RejectPrecisionLoss: true})
+	for _, input := range []string{"0.1", "3.1415927", "16777216", "1e-10", "-2.5", "NaN"} {
+		_, err := Parse[float32](input, opts)
+		assert.NoError(t, err, input)
+	}
+	for _, input := range []string{"3.14159265358979", "16777217", "0.100000001"} {
+		_, err := Parse[float32](input, opts)
+		assert.ErrorContains(t, err, "value loses precision", input)
+	}
+
+	v, err := Parse[float64]("3.14159265358979", opts)
+	assert.NoError(t, err)
+	assert.Equal(t, 3.14159265358979, v)
+}
@@ -1,5 +1,7 @@
 package stringable
 
+import "reflect"
+
 type Option func(o *options)
 
 func NoHybrid() Option {
@@ -14,12 +16,79 @@ func CompleteHybrid() Option {
 	}
 }
 
+// NoKindFallback prevents New from converting a named type, e.g. type Port
+// uint16, by the builtin adaptor of its underlying kind. Instead, only the
+// exact builtin types are supported by the builtin adaptors.
+func NoKindFallback() Option {
+	return func(o *options) {
+		o.Opt(optionNoKindFallback)
+	}
+}
+
+// Separator sets the separator used to join/split the elements of a slice or
+// an array, or the key/value pairs of a map. The default separator is a
+// comma (",").
+func Separator(sep string) Option {
+	return func(o *options) {
+		o.Separator = sep
+	}
+}
+
+// KeyValueSeparator sets the separator used to join/split the key and the
+// value of a key/value pair of a map. The default separator is an equal sign
+// ("=").
+func KeyValueSeparator(sep string) Option {
+	return func(o *options) {
+		o.KeyValueSeparator = sep
+	}
+}
+
+// NullValue sets the string representation of a nil pointer, e.g. when
+// converting a *int field. The default null value is an empty string.
+// FromString sets the pointer to nil if the input is the null value.
+func NullValue(s string) Option {
+	return func(o *options) {
+		o.NullValue = s
+	}
+}
+
 type options struct {
-	Value uint8
+	Value             uint8
+	Separator         string
+	KeyValueSeparator string
+	NullValue         string
+	Time              *TimeOptions
+	BytesEncoding     BytesEncoding
+	Int               *IntOptions
+	Float             *FloatOptions
+	Bool              *BoolOptions
+	Big               *BigOptions
+	Net               *NetOptions
+	URL               *URLOptions
+
+	// defaultValue is the value used by Bind when the key of a field is
+	// absent, set by the "default" key of a struct tag.
+	defaultValue *string
+
+	// joined is set by the "sep" key of a struct tag, Bind converts the
+	// slice field from a single value joined by the separator instead of the
+	// repeated keys then.
+	joined bool
+
+	// resolving is the chain of the composite types whose element types
+	// are being resolved, to detect the recursive types, e.g. type R []R.
+	resolving []reflect.Type
+
+	// err is the error occurred while building the options, e.g. an invalid
+	// struct tag, which is returned by New.
+	err error
 }
 
 func defaultOptions() *options {
-	return &options{}
+	return &options{
+		Separator:         ",",
+		KeyValueSeparator: "=",
+	}
 }
 
 func (o *options) Opt(v option) {
@@ -35,4 +104,5 @@ type option int
 const (
 	optionNoHybrid option = 1 << iota
 	optionCompleteHybrid
+	optionNoKindFallback
 )
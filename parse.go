@@ -0,0 +1,51 @@
+package stringable
+
+// Parse converts the string s to a value of type T, using the default
+// namespace. It's a shortcut of creating a Stringable from a pointer to a
+// zero value of T and calling its FromString method.
+func Parse[T any](s string, opts ...Option) (T, error) {
+	return ParseIn[T](defaultNS, s, opts...)
+}
+
+// MustParse is like Parse but panics if s cannot be parsed.
+func MustParse[T any](s string, opts ...Option) T {
+	return MustParseIn[T](defaultNS, s, opts...)
+}
+
+// Format converts the value v to a string, using the default namespace.
+// Unlike New, v doesn't have to be a pointer.
+func Format[T any](v T, opts ...Option) (string, error) {
+	return FormatIn(defaultNS, v, opts...)
+}
+
+// ParseIn is like Parse but uses the given namespace.
+func ParseIn[T any](ns *Namespace, s string, opts ...Option) (T, error) {
+	var v T
+	sb, err := ns.New(&v, opts...)
+	if err != nil {
+		return v, err
+	}
+	if err := sb.FromString(s); err != nil {
+		var zero T
+		return zero, err
+	}
+	return v, nil
+}
+
+// MustParseIn is like ParseIn but panics if s cannot be parsed.
+func MustParseIn[T any](ns *Namespace, s string, opts ...Option) T {
+	v, err := ParseIn[T](ns, s, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// FormatIn is like Format but uses the given namespace.
+func FormatIn[T any](ns *Namespace, v T, opts ...Option) (string, error) {
+	sb, err := ns.New(&v, opts...)
+	if err != nil {
+		return "", err
+	}
+	return sb.ToString()
+}
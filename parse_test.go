@@ -0,0 +1,62 @@
+package stringable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	i, err := Parse[int]("2045")
+	assert.NoError(t, err)
+	assert.Equal(t, 2045, i)
+
+	i, err = Parse[int]("hello")
+	assert.Error(t, err)
+	assert.Zero(t, i)
+
+	ids, err := Parse[[]int]("1;2;3", Separator(";"))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, ids)
+
+	_, err = Parse[StructNotStringable]("hello")
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+
+	cherry, err := Parse[StringMarshalerAndStringUnmarshalerCherry]("cherry")
+	assert.NoError(t, err)
+	assert.Equal(t, "FromString:cherry", cherry.Content)
+}
+
+func TestMustParse(t *testing.T) {
+	assert.Equal(t, time.Date(1991, 11, 10, 0, 0, 0, 0, time.UTC), MustParse[time.Time]("1991-11-10"))
+	assert.Panics(t, func() { MustParse[int]("hello") })
+}
+
+func TestFormat(t *testing.T) {
+	s, err := Format(true)
+	assert.NoError(t, err)
+	assert.Equal(t, "true", s)
+
+	s, err = Format(map[string]int{"b": 2, "a": 1}, KeyValueSeparator(":"))
+	assert.NoError(t, err)
+	assert.Equal(t, "a:1,b:2", s)
+
+	_, err = Format(StructNotStringable{})
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+}
+
+func TestParseInAndFormatIn(t *testing.T) {
+	ns := NewNamespace()
+	Adapt(ns, func(b *bool) (Stringable, error) { return (*YesNo)(b), nil })
+
+	b, err := ParseIn[bool](ns, "yes")
+	assert.NoError(t, err)
+	assert.True(t, b)
+	assert.True(t, MustParseIn[bool](ns, "yes"))
+	assert.Panics(t, func() { MustParseIn[bool](ns, "true") })
+
+	s, err := FormatIn(ns, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "no", s)
+}
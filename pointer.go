@@ -0,0 +1,63 @@
+package stringable
+
+import (
+	"reflect"
+)
+
+// createPointerStringable tries to create a Stringable for a pointer to a
+// pointer, e.g. **int. Returns nil if rv is not a pointer to a pointer.
+func (c *Namespace) createPointerStringable(rv reflect.Value, opts *options) (Stringable, error) {
+	if rv.Type().Elem().Kind() != reflect.Pointer {
+		return nil, nil
+	}
+
+	// Fail fast if the type being pointed to is not supported, or if the
+	// pointer type is recursive, e.g. type P *P.
+	child, err := resolvingOptions(rv.Type().Elem(), opts)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.createStringable(reflect.New(rv.Type().Elem().Elem()), child); err != nil {
+		return nil, err
+	}
+	return &pointer{ns: c, rv: rv.Elem(), opts: opts}, nil
+}
+
+// pointer is a Stringable of a pointer, which can be nil. A nil pointer is
+// converted to the null value specified in the options, and vice versa.
+// FromString allocates a new value to point to, so the value being pointed to
+// before won't be changed.
+type pointer struct {
+	ns   *Namespace
+	rv   reflect.Value // the addressable pointer
+	opts *options
+}
+
+func (p *pointer) ToString() (string, error) {
+	if p.rv.IsNil() {
+		return p.opts.NullValue, nil
+	}
+	sb, err := p.ns.createStringable(p.rv, p.opts)
+	if err != nil {
+		return "", err
+	}
+	return sb.ToString()
+}
+
+func (p *pointer) FromString(s string) error {
+	if s == p.opts.NullValue {
+		p.rv.Set(reflect.Zero(p.rv.Type()))
+		return nil
+	}
+
+	target := reflect.New(p.rv.Type().Elem())
+	sb, err := p.ns.createStringable(target, p.opts)
+	if err != nil {
+		return err
+	}
+	if err := sb.FromString(s); err != nil {
+		return err
+	}
+	p.rv.Set(target)
+	return nil
+}
@@ -0,0 +1,97 @@
+package stringable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_PointerToPointer(t *testing.T) {
+	var p *int
+	sb, err := New(&p)
+	assert.NoError(t, err)
+
+	got, err := sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "", got)
+
+	assert.NoError(t, sb.FromString("10"))
+	assert.Equal(t, 10, *p)
+	got, err = sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "10", got)
+
+	// The value being pointed to before won't be changed.
+	old := p
+	assert.NoError(t, sb.FromString("20"))
+	assert.Equal(t, 20, *p)
+	assert.Equal(t, 10, *old)
+
+	assert.Error(t, sb.FromString("hello"))
+	assert.Equal(t, 20, *p)
+
+	assert.NoError(t, sb.FromString(""))
+	assert.Nil(t, p)
+}
+
+func TestNew_MultiLevelPointer(t *testing.T) {
+	var ppt **time.Time
+	sb, err := New(&ppt)
+	assert.NoError(t, err)
+
+	assert.NoError(t, sb.FromString("1991-11-10"))
+	assert.Equal(t, time.Date(1991, 11, 10, 0, 0, 0, 0, time.UTC), **ppt)
+	got, err := sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "1991-11-10T00:00:00Z", got)
+
+	*ppt = nil
+	got, err = sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "", got)
+}
+
+func TestNew_PointerWithNullValue(t *testing.T) {
+	var p *string
+	sb, err := New(&p, NullValue("null"))
+	assert.NoError(t, err)
+
+	got, err := sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "null", got)
+
+	assert.NoError(t, sb.FromString(""))
+	assert.Equal(t, "", *p)
+
+	assert.NoError(t, sb.FromString("null"))
+	assert.Nil(t, p)
+}
+
+func TestNew_PointerToStringable(t *testing.T) {
+	var cherry *StringMarshalerAndStringUnmarshalerCherry
+	sb, err := New(&cherry)
+	assert.NoError(t, err)
+
+	assert.NoError(t, sb.FromString("cherry"))
+	assert.Equal(t, "FromString:cherry", cherry.Content)
+}
+
+func TestNew_SliceOfPointers(t *testing.T) {
+	var ps []*int
+	sb, err := New(&ps)
+	assert.NoError(t, err)
+
+	assert.NoError(t, sb.FromString("1,,3"))
+	assert.Len(t, ps, 3)
+	assert.Equal(t, 1, *ps[0])
+	assert.Nil(t, ps[1])
+	assert.Equal(t, 3, *ps[2])
+}
+
+func TestNew_PointerToUnsupportedType(t *testing.T) {
+	var p *StructNotStringable
+	sb, err := New(&p)
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+	assert.Nil(t, sb)
+}
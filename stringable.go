@@ -18,9 +18,22 @@ type StringUnmarshaler interface {
 }
 
 // New creates a Stringable instance from the given value. Note that
-// this method is a wrapper around the default namespace's New method.
-// Which means it doesn't support override/adapt existing types. Please
-// read Namespace.New to learn more.
-func New(v any) (Stringable, error) {
-	return defaultNS.New(v)
+// this method is a wrapper around the default namespace's New method,
+// which means only the adaptors registered in the default namespace, see
+// Default, are used. Please read Namespace.New to learn more.
+func New(v any, opts ...Option) (Stringable, error) {
+	return defaultNS.New(v, opts...)
+}
+
+// Default returns the default namespace used by the package-level functions,
+// e.g. New. Adaptors registered in it take effect globally:
+//
+//	stringable.Adapt(stringable.Default(), func(b *bool) (stringable.Stringable, error) {
+//		return (*YesNo)(b), nil
+//	})
+//
+// Libraries built on top of this package should prefer creating their own
+// namespaces, e.g. by Default().Child(), to avoid affecting each other.
+func Default() *Namespace {
+	return defaultNS
 }
@@ -116,6 +116,39 @@ func TestNew_Time(t *testing.T) {
 	assert.Error(t, sv.FromString("hello"))
 }
 
+func TestNew_Duration(t *testing.T) {
+	var d time.Duration = 90 * time.Minute
+	sv, err := New(&d)
+	assert.NoError(t, err)
+	got, err := sv.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "1h30m0s", got)
+
+	for input, expected := range map[string]time.Duration{
+		"1h30m":        90 * time.Minute,
+		"-1.5s":        -1500 * time.Millisecond,
+		"0":            0,
+		"3d12h":        84 * time.Hour,
+		"2w":           14 * 24 * time.Hour,
+		"1.5d":         36 * time.Hour,
+		"-1w1d1ms":     -(8*24*time.Hour + time.Millisecond),
+		"PT1H30M":      90 * time.Minute,
+		"P1DT12H":      36 * time.Hour,
+		"P2W":          14 * 24 * time.Hour,
+		"PT0,5S":       500 * time.Millisecond,
+		"-PT1M":        -time.Minute,
+		"PT1.25S":      1250 * time.Millisecond,
+		"P1W2DT3H4M5S": 9*24*time.Hour + 3*time.Hour + 4*time.Minute + 5*time.Second,
+	} {
+		assert.NoError(t, sv.FromString(input), input)
+		assert.Equal(t, expected, d, input)
+	}
+
+	for _, input := range []string{"", "hello", "1x", "3d12", "d", "P", "PT", "P1Y", "P1M", "PT1H1H", "100000000w", "15251w", "15250w48h"} {
+		assert.Error(t, sv.FromString(input), input)
+	}
+}
+
 func TestNew_ByteSlice(t *testing.T) {
 	var b []byte = []byte("hello")
 	rvByteSlice := reflect.ValueOf(b)
@@ -192,7 +225,7 @@ func testInteger[T Numeric](t *testing.T, vSuccess T, invalidStr string) {
 
 func testTime(t *testing.T, sv Stringable, fromStr string, expected time.Time, expectedToStr string) {
 	assert.NoError(t, sv.FromString(fromStr))
-	assert.True(t, equalTime(expected, time.Time(*sv.(*internal.Time))))
+	assert.True(t, equalTime(expected, time.Time(*sv.(*conversion).Stringable.(*internal.Time))))
 	ts, err := sv.ToString()
 	assert.NoError(t, err)
 	assert.Equal(t, expectedToStr, ts)
@@ -221,6 +254,31 @@ func getBuiltinInstances() []any {
 		complex64(1.0),
 		complex128(1.0),
 		time.Now(),
+		time.Second,
 		[]byte("hello"),
 	}
 }
+
+func TestNew_WithOptions(t *testing.T) {
+	apple := &TextMarshalerApple{}
+	sb, err := New(apple, NoHybrid())
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+	assert.Nil(t, sb)
+
+	sb, err = New(apple, CompleteHybrid())
+	assert.ErrorIs(t, err, ErrNotStringUnmarshaler)
+	assert.Nil(t, sb)
+}
+
+func TestDefault(t *testing.T) {
+	assert.Same(t, defaultNS, Default())
+
+	Adapt(Default(), func(b *bool) (Stringable, error) { return (*YesNo)(b), nil })
+	defer Unadapt[bool](Default())
+
+	var yesno bool
+	sb, err := New(&yesno)
+	assert.NoError(t, err)
+	assert.NoError(t, sb.FromString("yes"))
+	assert.True(t, yesno)
+}
@@ -0,0 +1,249 @@
+package stringable
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/ggicci/stringable/internal"
+)
+
+// WithStructField sets the options of a struct field from its struct tag,
+// so that the fields of the same type can be converted differently. The tag
+// is of the form:
+//
+//	stringable:"name,key=value,key=value"
+//
+// The name is used by Bind and Unbind. A backslash in a value escapes the
+// next character, e.g. `stringable:"tags,sep=\\,"`. The keys are:
+//
+//   - format: interpreted by the type of the field, or the type of the
+//     elements of a slice, an array, a map or a pointer. For time.Time:
+//     unix, unix_ms, unix_us, unix_ns, rfc3339, rfc3339nano, date, datetime
+//     or a layout, e.g. "02 Jan 06 15:04 MST". For integers: dec, bin, oct
+//     or hex, the output is prefixed, e.g. "0xff", and the Go syntax of
+//     integer literals is accepted while parsing, so "255" is still decimal
+//     and "0xff" is hex. For floats: a
+//     format of FloatOptions, e.g. ".2f". For bools: the true and false
+//     words separated by a slash, e.g. "yes/no". For []byte and [N]byte: the
+//     same as encoding;
+//   - encoding: the encoding of []byte and [N]byte, i.e. base64, base64raw,
+//     base64url, base64rawurl, hex, hexupper, base32, base32raw, base58, auto;
+//   - sep, kvsep, null: see Separator, KeyValueSeparator and NullValue. A
+//     slice field with sep is bound from a single joined value, instead of
+//     the repeated keys;
+//   - default: the value used by Bind when the key of the field is absent.
+//
+// An invalid tag, e.g. an unknown key, makes New return an error wrapping
+// ErrInvalidTag.
+//
+// Example:
+//
+//	type Event struct {
+//		Created time.Time `stringable:"created,format=unix_ms"`
+//		Digest  []byte    `stringable:"digest,encoding=hex"`
+//		Tags    []string  `stringable:"tags,sep=;"`
+//		Level   string    `stringable:"level,default=info"`
+//	}
+//
+//	sf, _ := reflect.TypeOf(Event{}).FieldByName("Created")
+//	stringable.New(&event.Created, stringable.WithStructField(sf))
+func WithStructField(sf reflect.StructField) Option {
+	return func(o *options) {
+		if err := applyTag(o, sf); err != nil {
+			o.err = fmt.Errorf("%w: field %s: %v", ErrInvalidTag, sf.Name, err)
+		}
+	}
+}
+
+func applyTag(o *options, sf reflect.StructField) error {
+	_, rest, _ := strings.Cut(sf.Tag.Get(TagName), ",")
+	kvs, err := parseTagOptions(rest)
+	if err != nil {
+		return err
+	}
+	for _, kv := range kvs {
+		key, value := kv[0], kv[1]
+		switch key {
+		case "format":
+			err = applyFormat(o, elemType(sf.Type), value)
+		case "encoding":
+			o.BytesEncoding, err = bytesEncoding(value)
+		case "sep":
+			o.Separator, o.joined = value, true
+		case "kvsep":
+			o.KeyValueSeparator = value
+		case "null":
+			o.NullValue = value
+		case "default":
+			o.defaultValue = &value
+		default:
+			err = fmt.Errorf("unknown key %q", key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseTagOptions parses the options of a tag, i.e. the part after the name,
+// into the key/value pairs.
+func parseTagOptions(s string) ([][2]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	raws, err := splitRaw(s, ",", -1)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(raws))
+	kvs := make([][2]string, 0, len(raws))
+	for _, raw := range raws {
+		key, rawValue, ok := strings.Cut(raw, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("option %q is not of the form key=value", raw)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate key %q", key)
+		}
+		seen[key] = true
+		value, err := unescape(rawValue)
+		if err != nil {
+			return nil, err
+		}
+		kvs = append(kvs, [2]string{key, value})
+	}
+	return kvs, nil
+}
+
+// elemType returns the type that a format applies to, i.e. the type of the
+// elements of a slice, an array, a map or a pointer, unless it's a builtin
+// type, e.g. []byte.
+func elemType(typ reflect.Type) reflect.Type {
+	for {
+		if _, ok := builtinStringableAdaptors[typ]; ok || isByteArray(typ) {
+			return typ
+		}
+		switch typ.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+			typ = typ.Elem()
+		default:
+			return typ
+		}
+	}
+}
+
+var (
+	tagTimeUnix = map[string]UnixPrecision{
+		"unix":    UnixSeconds,
+		"unix_ms": UnixMilliseconds,
+		"unix_us": UnixMicroseconds,
+		"unix_ns": UnixNanoseconds,
+	}
+	tagTimeLayouts = map[string]string{
+		"rfc3339":     time.RFC3339,
+		"rfc3339nano": time.RFC3339Nano,
+		"date":        time.DateOnly,
+		"datetime":    time.DateTime,
+	}
+	tagIntBases = map[string]int{
+		"dec": 10,
+		"bin": 2,
+		"oct": 8,
+		"hex": 16,
+	}
+	tagBytesEncodings = map[string]BytesEncoding{
+		"base64":       Base64,
+		"base64raw":    Base64Raw,
+		"base64url":    Base64URL,
+		"base64rawurl": Base64RawURL,
+		"hex":          HexLower,
+		"hexupper":     HexUpper,
+		"base32":       Base32,
+		"base32raw":    Base32Raw,
+		"base58":       Base58,
+		"auto":         AutoEncoding,
+	}
+)
+
+func applyFormat(o *options, typ reflect.Type, format string) error {
+	// The builtin types other than the basic ones, e.g. time.Duration and
+	// net.IP, are not configurable by their kinds.
+	_, builtin := builtinStringableAdaptors[typ]
+	special := builtin && typ != kindFallbackTypes[typ.Kind()]
+
+	switch {
+	case typ == typeOf[time.Time]():
+		var to TimeOptions
+		if o.Time != nil {
+			to = *o.Time
+		}
+		if precision, ok := tagTimeUnix[format]; ok {
+			to.UnixPrecision, to.OutputUnix = precision, precision
+		} else {
+			layout, ok := tagTimeLayouts[format]
+			if !ok {
+				layout = format
+			}
+			to.Layouts, to.OutputLayout = []string{layout}, layout
+		}
+		o.Time = &to
+		return nil
+	case typ == typeOf[[]byte]() || isByteArray(typ) ||
+		(!special && typ.Kind() == reflect.Slice && typ.Elem() == typeOf[uint8]()):
+		var err error
+		o.BytesEncoding, err = bytesEncoding(format)
+		return err
+	case special:
+		return fmt.Errorf("format is not supported for type %v", typ)
+	}
+
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		base, ok := tagIntBases[format]
+		if !ok {
+			return fmt.Errorf("unknown integer format %q, want one of dec, bin, oct, hex", format)
+		}
+		var io IntOptions
+		if o.Int != nil {
+			io = *o.Int
+		}
+		io.OutputBase, io.Prefixes = base, true
+		o.Int = &io
+	case reflect.Float32, reflect.Float64:
+		var fo FloatOptions
+		if o.Float != nil {
+			fo = *o.Float
+		}
+		fo.Format = format
+		if err := internal.ValidateFloatOptions(fo); err != nil {
+			return err
+		}
+		o.Float = &fo
+	case reflect.Bool:
+		t, f, ok := strings.Cut(format, "/")
+		if !ok || t == "" || f == "" || t == f {
+			return fmt.Errorf("bool format %q is not of the form true/false", format)
+		}
+		var bo BoolOptions
+		if o.Bool != nil {
+			bo = *o.Bool
+		}
+		words := BoolWords{True: t, False: f}
+		bo.Words, bo.Output = []BoolWords{words}, words
+		o.Bool = &bo
+	default:
+		return fmt.Errorf("format is not supported for type %v", typ)
+	}
+	return nil
+}
+
+func bytesEncoding(name string) (BytesEncoding, error) {
+	if enc, ok := tagBytesEncodings[name]; ok {
+		return enc, nil
+	}
+	return 0, fmt.Errorf("unknown encoding %q", name)
+}
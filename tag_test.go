@@ -0,0 +1,194 @@
+package stringable
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TaggedEvent struct {
+	Created  time.Time      `stringable:"created,format=unix_ms"`
+	Day      time.Time      `stringable:"day,format=date"`
+	Stamp    time.Time      `stringable:"stamp,format=02 Jan 06 15:04 MST"`
+	Digest   []byte         `stringable:"digest,encoding=hex"`
+	Key      [4]byte        `stringable:"key,format=base64"`
+	Tags     []string       `stringable:"tags,sep=;"`
+	Escaped  []string       `stringable:"escaped,sep=\\,\\,"`
+	Labels   map[string]int `stringable:"labels,sep=;,kvsep=:"`
+	Mode     uint32         `stringable:"mode,format=oct"`
+	Masks    []uint16       `stringable:"mask,format=hex"`
+	Ratio    float64        `stringable:"ratio,format=.2f"`
+	Enabled  *bool          `stringable:"enabled,format=on/off,null=unset"`
+	Level    string         `stringable:"level,default=info"`
+	Attempts int            `stringable:"attempts,default=3"`
+}
+
+func structField(t *testing.T, v any, name string) reflect.StructField {
+	sf, ok := reflect.TypeOf(v).FieldByName(name)
+	assert.True(t, ok)
+	return sf
+}
+
+func TestWithStructField(t *testing.T) {
+	var event TaggedEvent
+	sb, err := New(&event.Created, WithStructField(structField(t, event, "Created")))
+	assert.NoError(t, err)
+	assert.NoError(t, sb.FromString("1700000000123"))
+	assert.Equal(t, time.UnixMilli(1700000000123).UTC(), event.Created)
+	s, err := sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "1700000000123", s)
+
+	sb, err = New(&event.Tags, WithStructField(structField(t, event, "Tags")))
+	assert.NoError(t, err)
+	assert.NoError(t, sb.FromString("a,b;c"))
+	assert.Equal(t, []string{"a,b", "c"}, event.Tags)
+
+	sb, err = New(&event.Escaped, WithStructField(structField(t, event, "Escaped")))
+	assert.NoError(t, err)
+	assert.NoError(t, sb.FromString("a,b,,c"))
+	assert.Equal(t, []string{"a,b", "c"}, event.Escaped)
+
+	// The per-call options are applied after the namespace defaults.
+	ns := NewNamespace(WithDefaultOptions(Separator("|")))
+	sb, err = ns.New(&event.Tags, WithStructField(structField(t, event, "Tags")))
+	assert.NoError(t, err)
+	assert.NoError(t, sb.FromString("x|y;z"))
+	assert.Equal(t, []string{"x|y", "z"}, event.Tags)
+}
+
+func TestBind_TagOptions(t *testing.T) {
+	var event TaggedEvent
+	err := Bind(&event, map[string][]string{
+		"created": {"1700000000123"},
+		"day":     {"2024-03-01"},
+		"stamp":   {"01 Mar 24 10:30 UTC"},
+		"digest":  {"cafe"},
+		"key":     {"AQIDBA=="},
+		"tags":    {"a;b"},
+		"escaped": {"a,,b"},
+		"labels":  {"x:1;y:2"},
+		"mode":    {"0o755"},
+		"mask":    {"0xff", "0x0f"},
+		"ratio":   {"0.125"},
+		"enabled": {"on"},
+		"level":   {"debug"},
+	})
+	assert.NoError(t, err)
+
+	enabled := true
+	expected := TaggedEvent{
+		Created:  time.UnixMilli(1700000000123).UTC(),
+		Day:      time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Stamp:    time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC),
+		Digest:   []byte{0xca, 0xfe},
+		Key:      [4]byte{1, 2, 3, 4},
+		Tags:     []string{"a", "b"},
+		Escaped:  []string{"a", "b"},
+		Labels:   map[string]int{"x": 1, "y": 2},
+		Mode:     0o755,
+		Masks:    []uint16{0xff, 0x0f},
+		Ratio:    0.125,
+		Enabled:  &enabled,
+		Level:    "debug",
+		Attempts: 3,
+	}
+	assert.Equal(t, expected, event)
+
+	values, err := Unbind(event)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"created":  {"1700000000123"},
+		"day":      {"2024-03-01"},
+		"stamp":    {"01 Mar 24 10:30 UTC"},
+		"digest":   {"cafe"},
+		"key":      {"AQIDBA=="},
+		"tags":     {"a;b"},
+		"escaped":  {"a,,b"},
+		"labels":   {"x:1;y:2"},
+		"mode":     {"0o755"},
+		"mask":     {"0xff", "0xf"},
+		"ratio":    {"0.12"},
+		"enabled":  {"on"},
+		"level":    {"debug"},
+		"attempts": {"3"},
+	}, values)
+
+	event.Enabled = nil
+	values, err = Unbind(event)
+	assert.NoError(t, err)
+	assert.NotContains(t, values, "enabled")
+
+	assert.NoError(t, Bind(&event, map[string][]string{"enabled": {"unset"}}))
+	assert.Nil(t, event.Enabled)
+}
+
+func TestWithStructField_Invalid(t *testing.T) {
+	for _, c := range []struct {
+		value any
+		err   string
+	}{
+		{&struct {
+			V int `stringable:"v,unknown=1"`
+		}{}, `unknown key "unknown"`},
+		{&struct {
+			V int `stringable:"v,sep"`
+		}{}, `option "sep" is not of the form key=value`},
+		{&struct {
+			V int `stringable:"v,sep=;,sep=|"`
+		}{}, `duplicate key "sep"`},
+		{&struct {
+			V int `stringable:"v,format=base7"`
+		}{}, `unknown integer format "base7"`},
+		{&struct {
+			V bool `stringable:"v,format=yes"`
+		}{}, `bool format "yes" is not of the form true/false`},
+		{&struct {
+			V []byte `stringable:"v,encoding=base2"`
+		}{}, `unknown encoding "base2"`},
+		{&struct {
+			V time.Duration `stringable:"v,format=hex"`
+		}{}, "format is not supported for type time.Duration"},
+		{&struct {
+			V net.IP `stringable:"v,format=hex"`
+		}{}, "format is not supported for type net.IP"},
+		{&struct {
+			V string `stringable:"v,format=upper"`
+		}{}, "format is not supported for type string"},
+		{&struct {
+			V float64 `stringable:"v,format=zz"`
+		}{}, `invalid float format "zz"`},
+		{&struct {
+			V string `stringable:"v,default=\"oops"`
+		}{}, "invalid syntax: unterminated quote"},
+	} {
+		rv := reflect.ValueOf(c.value).Elem()
+		_, err := New(rv.Field(0).Addr().Interface(), WithStructField(rv.Type().Field(0)))
+		assert.ErrorIs(t, err, ErrInvalidTag)
+		assert.ErrorContains(t, err, c.err)
+
+		err = Bind(c.value, map[string][]string{"v": {"1"}})
+		assert.ErrorIs(t, err, ErrInvalidTag)
+		assert.ErrorContains(t, err, `field "v": invalid struct tag: field V: `+c.err)
+	}
+}
+
+func TestBind_IntegerFormat(t *testing.T) {
+	var v struct {
+		Workers int    `stringable:"workers,format=hex"`
+		Mode    uint32 `stringable:"mode,format=oct"`
+	}
+	assert.NoError(t, Bind(&v, map[string][]string{"workers": {"16"}, "mode": {"493"}}))
+	assert.Equal(t, 16, v.Workers, "unprefixed input should be decimal")
+	assert.Equal(t, uint32(0o755), v.Mode)
+
+	assert.NoError(t, Bind(&v, map[string][]string{"workers": {"0x16"}, "mode": {"0o755"}}))
+	assert.Equal(t, 0x16, v.Workers)
+
+	values, err := Unbind(v)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"workers": {"0x16"}, "mode": {"0o755"}}, values)
+}
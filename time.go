@@ -0,0 +1,37 @@
+package stringable
+
+import "github.com/ggicci/stringable/internal"
+
+// TimeOptions configures how a time.Time is converted from/to a string, see
+// WithTime. The zero value behaves the same as the default conversion.
+type TimeOptions = internal.TimeOptions
+
+// UnixPrecision is the precision of a unix timestamp, see
+// TimeOptions.UnixPrecision and TimeOptions.OutputUnix.
+type UnixPrecision = internal.UnixPrecision
+
+const (
+	UnixSeconds      = internal.UnixSeconds
+	UnixMilliseconds = internal.UnixMilliseconds
+	UnixMicroseconds = internal.UnixMicroseconds
+	UnixNanoseconds  = internal.UnixNanoseconds
+	UnixAuto         = internal.UnixAuto // detects the precision by the magnitude
+)
+
+// WithTime sets the options used to convert time.Time values. The given
+// options replace the ones set before, e.g. by the default options of a
+// namespace.
+//
+// Example:
+//
+//	stringable.New(&t, stringable.WithTime(stringable.TimeOptions{
+//		Layouts:         []string{time.RFC1123, "2006-01-02 15:04:05"},
+//		OutputLayout:    time.RFC1123,
+//		PreserveOffset:  true,
+//		DefaultLocation: time.Local,
+//	}))
+func WithTime(o TimeOptions) Option {
+	return func(opts *options) {
+		opts.Time = &o
+	}
+}
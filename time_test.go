@@ -0,0 +1,222 @@
+package stringable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTime_Layouts(t *testing.T) {
+	shanghai := time.FixedZone("Asia/Shanghai", +8*3600)
+
+	var tm time.Time
+	sb, err := New(&tm, WithTime(TimeOptions{
+		Layouts:         []string{time.RFC1123, "2006-01-02 15:04:05"},
+		OutputLayout:    time.RFC1123,
+		DefaultLocation: shanghai,
+	}))
+	assert.NoError(t, err)
+
+	assert.NoError(t, sb.FromString("2006-01-02 15:04:05"))
+	assert.True(t, time.Date(2006, 1, 2, 15, 4, 5, 0, shanghai).Equal(tm))
+	assert.Equal(t, time.UTC, tm.Location())
+	got, err := sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "Mon, 02 Jan 2006 07:04:05 UTC", got)
+
+	assert.NoError(t, sb.FromString("Sun, 10 Nov 1991 08:00:00 UTC"))
+	assert.True(t, time.Date(1991, 11, 10, 8, 0, 0, 0, time.UTC).Equal(tm))
+
+	// The default layouts are not accepted.
+	assert.Error(t, sb.FromString("1991-11-10T08:00:00Z"))
+	assert.Error(t, sb.FromString("678088800"))
+}
+
+func TestWithTime_PreserveOffset(t *testing.T) {
+	var tm time.Time
+	sb, err := New(&tm, WithTime(TimeOptions{PreserveOffset: true}))
+	assert.NoError(t, err)
+
+	assert.NoError(t, sb.FromString("1991-11-10T08:00:00+08:00"))
+	_, offset := tm.Zone()
+	assert.Equal(t, 8*3600, offset)
+	got, err := sb.ToString()
+	assert.NoError(t, err)
+	assert.Equal(t, "1991-11-10T08:00:00+08:00", got)
+
+	// The default layouts are accepted.
+	assert.NoError(t, sb.FromString("678088800"))
+	assert.True(t, time.Date(1991, 6, 28, 6, 0, 0, 0, time.UTC).Equal(tm))
+	assert.Error(t, sb.FromString("hello"))
+}
+
+func TestWithTime_OutputLocation(t *testing.T) {
+	tm := time.Date(1991, 11, 10, 0, 0, 0, 0, time.UTC)
+	s, err := Format(tm, WithTime(TimeOptions{
+		OutputLayout:   "2006-01-02 15:04:05 -0700",
+		OutputLocation: time.FixedZone("", -5*3600),
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, "1991-11-09 19:00:00 -0500", s)
+}
+
+func TestWithTime_NamespaceDefaults(t *testing.T) {
+	org := NewNamespace(WithDefaultOptions(WithTime(TimeOptions{OutputLayout: time.DateOnly}), Separator(";")))
+	service := NewNamespace(WithParent(org), WithDefaultOptions(Separator("|")))
+
+	times := []time.Time{
+		time.Date(1991, 11, 10, 0, 0, 0, 0, time.UTC),
+		time.Date(2006, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	s, err := FormatIn(org, times)
+	assert.NoError(t, err)
+	assert.Equal(t, "1991-11-10;2006-01-02", s)
+
+	s, err = FormatIn(service, times)
+	assert.NoError(t, err)
+	assert.Equal(t, "1991-11-10|2006-01-02", s)
+
+	// Options passed to New take precedence.
+	s, err = FormatIn(service, times, WithTime(TimeOptions{OutputLayout: "2006"}))
+	assert.NoError(t, err)
+	assert.Equal(t, "1991|2006", s)
+}
+
+func TestNew_TimeNegativeUnixtime(t *testing.T) {
+	var tm time.Time
+	sb, err := New(&tm)
+	assert.NoError(t, err)
+
+	assert.NoError(t, sb.FromString("-86400"))
+	assert.True(t, time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC).Equal(tm))
+
+	assert.NoError(t, sb.FromString("-1.5"))
+	assert.True(t, time.Date(1969, 12, 31, 23, 59, 58, 500000000, time.UTC).Equal(tm))
+
+	assert.Error(t, sb.FromString("99999999999999999999"))
+}
+
+func TestWithTime_UnixPrecision(t *testing.T) {
+	expected := time.Date(2021, 4, 21, 3, 15, 33, 284000000, time.UTC)
+	for _, c := range []struct {
+		precision UnixPrecision
+		input     string
+	}{
+		{UnixSeconds, "1618974933.284"},
+		{UnixMilliseconds, "1618974933284"},
+		{UnixMicroseconds, "1618974933284000"},
+		{UnixNanoseconds, "1618974933284000000"},
+		{UnixAuto, "1618974933.284"},
+		{UnixAuto, "1618974933284"},
+		{UnixAuto, "1618974933284000.0"},
+		{UnixAuto, "1618974933284000000"},
+	} {
+		tm, err := Parse[time.Time](c.input, WithTime(TimeOptions{UnixPrecision: c.precision}))
+		assert.NoError(t, err, c.input)
+		assert.True(t, expected.Equal(tm.Truncate(time.Millisecond)), c.input)
+	}
+
+	tm, err := Parse[time.Time]("-86400000", WithTime(TimeOptions{UnixPrecision: UnixMilliseconds}))
+	assert.NoError(t, err)
+	assert.True(t, time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC).Equal(tm))
+
+	tm, err = Parse[time.Time]("1.99999999", WithTime(TimeOptions{UnixPrecision: UnixMilliseconds}))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1999999), tm.UnixNano(), "truncated to nanoseconds")
+
+	tm, err = Parse[time.Time]("-1.5", WithTime(TimeOptions{UnixPrecision: UnixMilliseconds}))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-1500000), tm.UnixNano())
+
+	// Unix timestamps are accepted along with the given layouts.
+	opts := WithTime(TimeOptions{Layouts: []string{time.DateOnly}, UnixPrecision: UnixAuto})
+	_, err = Parse[time.Time]("1618974933284", opts)
+	assert.NoError(t, err)
+	_, err = Parse[time.Time]("2021-04-21", opts)
+	assert.NoError(t, err)
+}
+
+func TestWithTime_OutputUnix(t *testing.T) {
+	tm := time.Date(2021, 4, 21, 3, 15, 33, 284123456, time.UTC)
+	for precision, expected := range map[UnixPrecision]string{
+		UnixSeconds:      "1618974933",
+		UnixAuto:         "1618974933",
+		UnixMilliseconds: "1618974933284",
+		UnixMicroseconds: "1618974933284123",
+		UnixNanoseconds:  "1618974933284123456",
+	} {
+		s, err := Format(tm, WithTime(TimeOptions{OutputUnix: precision}))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, s)
+	}
+
+	s, err := Format(time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC), WithTime(TimeOptions{OutputUnix: UnixSeconds}))
+	assert.NoError(t, err)
+	assert.Equal(t, "-86400", s)
+}
+
+func TestWithTime_Relative(t *testing.T) {
+	// Wednesday
+	now := time.Date(2021, 4, 21, 15, 30, 45, 123, time.UTC)
+	opts := WithTime(TimeOptions{
+		Relative: true,
+		Now:      func() time.Time { return now },
+	})
+
+	for input, expected := range map[string]time.Time{
+		"now":          now,
+		"NOW":          now,
+		"today":        time.Date(2021, 4, 21, 0, 0, 0, 0, time.UTC),
+		"yesterday":    time.Date(2021, 4, 20, 0, 0, 0, 0, time.UTC),
+		"tomorrow":     time.Date(2021, 4, 22, 0, 0, 0, 0, time.UTC),
+		"now-15m":      now.Add(-15 * time.Minute),
+		"now+2d/d":     time.Date(2021, 4, 23, 0, 0, 0, 0, time.UTC),
+		"now-1M/M":     time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+		"now/w":        time.Date(2021, 4, 19, 0, 0, 0, 0, time.UTC),
+		"now/y":        time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		"now-1y+2h/h":  time.Date(2020, 4, 21, 17, 0, 0, 0, time.UTC),
+		"now/s":        time.Date(2021, 4, 21, 15, 30, 45, 0, time.UTC),
+		"now/m+30s":    time.Date(2021, 4, 21, 15, 30, 30, 0, time.UTC),
+		"now-1w":       now.AddDate(0, 0, -7),
+		"yesterday+1h": time.Date(2021, 4, 20, 1, 0, 0, 0, time.UTC),
+		"2021-04-21":   time.Date(2021, 4, 21, 0, 0, 0, 0, time.UTC),
+	} {
+		tm, err := Parse[time.Time](input, opts)
+		assert.NoError(t, err, input)
+		assert.True(t, expected.Equal(tm), "%s: expected %v, got %v", input, expected, tm)
+	}
+
+	for _, input := range []string{"now-", "now-15", "now-15x", "now/", "now/x", "now*2", "nowhere", "now-99999999999999999999d"} {
+		_, err := Parse[time.Time](input, opts)
+		assert.Error(t, err, input)
+	}
+
+	// Not enabled by default.
+	_, err := Parse[time.Time]("now")
+	assert.Error(t, err)
+
+	// A keyword not followed by an operation is left to the layouts.
+	_, err = Parse[time.Time]("nowhere", opts)
+	assert.NotContains(t, err.Error(), "relative time")
+	tm, err := Parse[time.Time]("today: 2021-04-20", WithTime(TimeOptions{
+		Relative: true,
+		Now:      func() time.Time { return now },
+		Layouts:  []string{"today: 2006-01-02"},
+	}))
+	assert.NoError(t, err)
+	assert.True(t, time.Date(2021, 4, 20, 0, 0, 0, 0, time.UTC).Equal(tm))
+}
+
+func TestWithTime_RelativeInLocation(t *testing.T) {
+	shanghai := time.FixedZone("Asia/Shanghai", +8*3600)
+	now := time.Date(2021, 4, 21, 20, 0, 0, 0, time.UTC) // 2021-04-22 04:00 in Shanghai
+
+	tm, err := Parse[time.Time]("today", WithTime(TimeOptions{
+		Relative:        true,
+		Now:             func() time.Time { return now },
+		DefaultLocation: shanghai,
+	}))
+	assert.NoError(t, err)
+	assert.True(t, time.Date(2021, 4, 22, 0, 0, 0, 0, shanghai).Equal(tm))
+}
@@ -0,0 +1,22 @@
+package stringable
+
+import "github.com/ggicci/stringable/internal"
+
+// URLOptions configures how url.URL is converted from/to a string, see
+// WithURL.
+type URLOptions = internal.URLOptions
+
+// WithURL sets the options used to convert url.URL values.
+//
+// Example:
+//
+//	stringable.New(&callback, stringable.WithURL(stringable.URLOptions{
+//		RequireAbsolute: true,
+//		Schemes:         []string{"https"},
+//		ForbidUserinfo:  true,
+//	}))
+func WithURL(o URLOptions) Option {
+	return func(opts *options) {
+		opts.URL = &o
+	}
+}